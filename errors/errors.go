@@ -1,6 +1,13 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type configError struct {
 	message string
@@ -26,6 +33,10 @@ func ErrInvalidMode(mode string) error {
 	return &configError{message: fmt.Sprintf("invalid mode: '%s' (must be debug, release, or test)", mode)}
 }
 
+func ErrRouteConflicts(conflicts []string) error {
+	return &configError{message: fmt.Sprintf("%d invalid route(s): %s", len(conflicts), strings.Join(conflicts, "; "))}
+}
+
 type RuntimeError struct {
 	message string
 	cause   error
@@ -56,127 +67,322 @@ func ErrNotStarted() error {
 
 // HTTP Domain Errors
 
-type NotFoundError struct {
-	message string
+// HTTPError is implemented by every HTTP domain error
+// ErrorHandler finds it anywhere in the error chain (errors.As), so domain errors can be wrapped:
+// fmt.Errorf("loading order: %w", NewNotFoundError("order not found")) is still a 404.
+type HTTPError interface {
+	error
+
+	// Status returns the HTTP status code of the error
+	Status() int
+
+	// Code returns the stable machine-readable error code (e.g., "ORDER_NOT_FOUND"), if any
+	Code() string
+
+	// Details returns the metadata exposed to clients (e.g., {"order_id": "42"}), if any
+	Details() map[string]any
+
+	// PublicMessage returns the message sent to clients
+	PublicMessage() string
+
+	// Headers returns the response headers of the error (e.g., Retry-After), if any
+	Headers() http.Header
+}
+
+// ErrorOption configures an HTTP domain error
+type ErrorOption func(*httpError)
+
+// WithErrorCode sets a stable machine-readable code returned to clients
+func WithErrorCode(code string) ErrorOption {
+	return func(e *httpError) {
+		e.code = code
+	}
+}
+
+// WithErrorDetail adds a metadata entry returned to clients
+func WithErrorDetail(key string, value any) ErrorOption {
+	return func(e *httpError) {
+		if e.details == nil {
+			e.details = make(map[string]any)
+		}
+		e.details[key] = value
+	}
+}
+
+// WithErrorDetails adds metadata entries returned to clients
+func WithErrorDetails(details map[string]any) ErrorOption {
+	return func(e *httpError) {
+		for key, value := range details {
+			WithErrorDetail(key, value)(e)
+		}
+	}
+}
+
+// WithErrorCause sets the underlying error (available through errors.Unwrap, never sent to clients)
+func WithErrorCause(cause error) ErrorOption {
+	return func(e *httpError) {
+		e.cause = cause
+	}
+}
+
+// WithInternalMessage sets a message used in logs instead of the public message
+func WithInternalMessage(msg string) ErrorOption {
+	return func(e *httpError) {
+		e.internal = msg
+	}
+}
+
+// WithErrorHeader adds a response header written alongside the error body
+func WithErrorHeader(key, value string) ErrorOption {
+	return func(e *httpError) {
+		if e.headers == nil {
+			e.headers = make(http.Header)
+		}
+		e.headers.Add(key, value)
+	}
+}
+
+// WithRetryAfter sets the Retry-After header to a delay, in whole seconds (rounded up)
+// Typically used with TooManyRequestsError and ServiceUnavailableError.
+func WithRetryAfter(delay time.Duration) ErrorOption {
+	seconds := int64(math.Ceil(delay.Seconds()))
+	if seconds < 0 {
+		seconds = 0
+	}
+	return func(e *httpError) {
+		WithErrorHeader("Retry-After", strconv.FormatInt(seconds, 10))(e)
+	}
+}
+
+// WithRetryAfterDate sets the Retry-After header to a date (HTTP-date format)
+func WithRetryAfterDate(date time.Time) ErrorOption {
+	return func(e *httpError) {
+		WithErrorHeader("Retry-After", date.UTC().Format(http.TimeFormat))(e)
+	}
+}
+
+// WithAuthenticateChallenge adds a WWW-Authenticate challenge (e.g., `Bearer realm="api", error="invalid_token"`)
+// Typically used with UnauthorizedError; each call adds a challenge.
+func WithAuthenticateChallenge(challenge string) ErrorOption {
+	return func(e *httpError) {
+		WithErrorHeader("WWW-Authenticate", challenge)(e)
+	}
+}
+
+// WithAllowHeader sets the Allow header listing the methods supported by the resource (405 responses)
+func WithAllowHeader(methods ...string) ErrorOption {
+	return func(e *httpError) {
+		if e.headers == nil {
+			e.headers = make(http.Header)
+		}
+		e.headers.Set("Allow", strings.Join(methods, ", "))
+	}
+}
+
+// httpError holds the data shared by every HTTP domain error
+type httpError struct {
+	message  string // Public message, sent to clients
+	internal string // Internal message, only logged
+	code     string
+	details  map[string]any
+	headers  http.Header
+	cause    error
+}
+
+// newHTTPError applies the options to a new httpError
+func newHTTPError(msg string, opts []ErrorOption) httpError {
+	e := httpError{message: msg}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e
 }
 
-func (e *NotFoundError) Error() string {
+// Error returns the internal message (or the public one) followed by the cause
+func (e *httpError) Error() string {
+	msg := e.message
+	if e.internal != "" {
+		msg = e.internal
+	}
+	if e.cause != nil {
+		return msg + ": " + e.cause.Error()
+	}
+	return msg
+}
+
+func (e *httpError) Code() string {
+	return e.code
+}
+
+func (e *httpError) Details() map[string]any {
+	return e.details
+}
+
+func (e *httpError) PublicMessage() string {
 	return e.message
 }
 
-func NewNotFoundError(msg string) error {
-	return &NotFoundError{message: msg}
+func (e *httpError) Headers() http.Header {
+	return e.headers
+}
+
+func (e *httpError) Unwrap() error {
+	return e.cause
+}
+
+type NotFoundError struct {
+	httpError
+}
+
+func (e *NotFoundError) Status() int {
+	return http.StatusNotFound
+}
+
+func NewNotFoundError(msg string, opts ...ErrorOption) error {
+	return &NotFoundError{httpError: newHTTPError(msg, opts)}
 }
 
 type UnauthorizedError struct {
-	message string
+	httpError
 }
 
-func (e *UnauthorizedError) Error() string {
-	return e.message
+func (e *UnauthorizedError) Status() int {
+	return http.StatusUnauthorized
 }
 
-func NewUnauthorizedError(msg string) error {
-	return &UnauthorizedError{message: msg}
+func NewUnauthorizedError(msg string, opts ...ErrorOption) error {
+	return &UnauthorizedError{httpError: newHTTPError(msg, opts)}
 }
 
 type ConflictError struct {
-	message string
+	httpError
 }
 
-func (e *ConflictError) Error() string {
-	return e.message
+func (e *ConflictError) Status() int {
+	return http.StatusConflict
 }
 
-func NewConflictError(msg string) error {
-	return &ConflictError{message: msg}
+func NewConflictError(msg string, opts ...ErrorOption) error {
+	return &ConflictError{httpError: newHTTPError(msg, opts)}
 }
 
 type BadRequestError struct {
-	message string
+	httpError
 }
 
-func (e *BadRequestError) Error() string {
-	return e.message
+func (e *BadRequestError) Status() int {
+	return http.StatusBadRequest
 }
 
-func NewBadRequestError(msg string) error {
-	return &BadRequestError{message: msg}
+func NewBadRequestError(msg string, opts ...ErrorOption) error {
+	return &BadRequestError{httpError: newHTTPError(msg, opts)}
 }
 
 type ExternalServiceError struct {
-	message string
-	status  int
-}
-
-func (e *ExternalServiceError) Error() string {
-	return e.message
+	httpError
+	status int
 }
 
 func (e *ExternalServiceError) Status() int {
 	return e.status
 }
 
-func NewExternalServiceError(msg string, status int) error {
-	return &ExternalServiceError{message: msg, status: status}
+func NewExternalServiceError(msg string, status int, opts ...ErrorOption) error {
+	return &ExternalServiceError{httpError: newHTTPError(msg, opts), status: status}
 }
 
 type ForbiddenError struct {
-	message string
+	httpError
 }
 
-func (e *ForbiddenError) Error() string {
-	return e.message
+func (e *ForbiddenError) Status() int {
+	return http.StatusForbidden
 }
 
-func NewForbiddenError(msg string) error {
-	return &ForbiddenError{message: msg}
+func NewForbiddenError(msg string, opts ...ErrorOption) error {
+	return &ForbiddenError{httpError: newHTTPError(msg, opts)}
 }
 
 type UnprocessableEntityError struct {
-	message string
+	httpError
 }
 
-func (e *UnprocessableEntityError) Error() string {
-	return e.message
+func (e *UnprocessableEntityError) Status() int {
+	return http.StatusUnprocessableEntity
 }
 
-func NewUnprocessableEntityError(msg string) error {
-	return &UnprocessableEntityError{message: msg}
+func NewUnprocessableEntityError(msg string, opts ...ErrorOption) error {
+	return &UnprocessableEntityError{httpError: newHTTPError(msg, opts)}
 }
 
 type TooManyRequestsError struct {
-	message string
+	httpError
 }
 
-func (e *TooManyRequestsError) Error() string {
-	return e.message
+func (e *TooManyRequestsError) Status() int {
+	return http.StatusTooManyRequests
+}
+
+func NewTooManyRequestsError(msg string, opts ...ErrorOption) error {
+	return &TooManyRequestsError{httpError: newHTTPError(msg, opts)}
+}
+
+type MethodNotAllowedError struct {
+	httpError
+}
+
+func (e *MethodNotAllowedError) Status() int {
+	return http.StatusMethodNotAllowed
+}
+
+func NewMethodNotAllowedError(msg string, opts ...ErrorOption) error {
+	return &MethodNotAllowedError{httpError: newHTTPError(msg, opts)}
+}
+
+type NotAcceptableError struct {
+	httpError
 }
 
-func NewTooManyRequestsError(msg string) error {
-	return &TooManyRequestsError{message: msg}
+func (e *NotAcceptableError) Status() int {
+	return http.StatusNotAcceptable
+}
+
+func NewNotAcceptableError(msg string, opts ...ErrorOption) error {
+	return &NotAcceptableError{httpError: newHTTPError(msg, opts)}
+}
+
+type UnsupportedMediaTypeError struct {
+	httpError
+}
+
+func (e *UnsupportedMediaTypeError) Status() int {
+	return http.StatusUnsupportedMediaType
+}
+
+func NewUnsupportedMediaTypeError(msg string, opts ...ErrorOption) error {
+	return &UnsupportedMediaTypeError{httpError: newHTTPError(msg, opts)}
 }
 
 type InternalServerError struct {
-	message string
+	httpError
 }
 
-func (e *InternalServerError) Error() string {
-	return e.message
+func (e *InternalServerError) Status() int {
+	return http.StatusInternalServerError
 }
 
-func NewInternalServerError(msg string) error {
-	return &InternalServerError{message: msg}
+func NewInternalServerError(msg string, opts ...ErrorOption) error {
+	return &InternalServerError{httpError: newHTTPError(msg, opts)}
 }
 
 type ServiceUnavailableError struct {
-	message string
+	httpError
 }
 
-func (e *ServiceUnavailableError) Error() string {
-	return e.message
+func (e *ServiceUnavailableError) Status() int {
+	return http.StatusServiceUnavailable
 }
 
-func NewServiceUnavailableError(msg string) error {
-	return &ServiceUnavailableError{message: msg}
+func NewServiceUnavailableError(msg string, opts ...ErrorOption) error {
+	return &ServiceUnavailableError{httpError: newHTTPError(msg, opts)}
 }
@@ -2,6 +2,7 @@ package httpplatform
 
 import (
 	"github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/httpclient"
 	"github.com/edaniel30/http-platform-go/middleware"
 	config "github.com/edaniel30/http-platform-go/models"
 )
@@ -10,6 +11,47 @@ import (
 // Returns a Config with sensible defaults
 var DefaultConfig = config.DefaultConfig
 
+// Error response formats for WithErrorFormat
+const (
+	ErrorFormatAPIError = config.ErrorFormatAPIError
+	ErrorFormatProblem  = config.ErrorFormatProblem
+)
+
+// Telemetry exporters (Config.TelemetryExporter)
+const (
+	TelemetryExporterOTLP   = config.TelemetryExporterOTLP
+	TelemetryExporterStdout = config.TelemetryExporterStdout
+	TelemetryExporterFile   = config.TelemetryExporterFile
+)
+
+// Telemetry initialization modes (Config.TelemetryInitMode)
+const (
+	TelemetryInitLenient = config.TelemetryInitLenient
+	TelemetryInitStrict  = config.TelemetryInitStrict
+	TelemetryInitRetry   = config.TelemetryInitRetry
+)
+
+// Telemetry resource detectors for WithTelemetryResourceDetectors
+const (
+	ResourceDetectorHost       = config.ResourceDetectorHost
+	ResourceDetectorProcess    = config.ResourceDetectorProcess
+	ResourceDetectorContainer  = config.ResourceDetectorContainer
+	ResourceDetectorKubernetes = config.ResourceDetectorKubernetes
+)
+
+// OTLP protocols for WithOTLPProtocol
+const (
+	OTLPProtocolHTTP = config.OTLPProtocolHTTP
+	OTLPProtocolGRPC = config.OTLPProtocolGRPC
+)
+
+// SamplingRule sets the trace sampling ratio of a route (Config.TelemetrySamplingRules)
+// Example: httpplatform.SamplingRule{Route: "/health", Ratio: 0}
+type SamplingRule = config.SamplingRule
+
+// OTLPRetryConfig configures the retry of failed OTLP exports (Config.OTLPRetry)
+type OTLPRetryConfig = config.OTLPRetryConfig
+
 var (
 	// WithPort sets the HTTP server port (default: 8080)
 	WithPort = config.WithPort
@@ -50,6 +92,10 @@ var (
 	// WithMaxAge sets the maximum time (in seconds) that preflight request results can be cached
 	WithMaxAge = config.WithMaxAge
 
+	// WithAllowOriginFunc sets a callback consulted for origins that don't match AllowedOrigins
+	// The callback receives the request context (e.g., to resolve tenant-specific origins)
+	WithAllowOriginFunc = config.WithAllowOriginFunc
+
 	// WithoutTraceID disables the TraceID middleware
 	WithoutTraceID = config.WithoutTraceID
 
@@ -68,6 +114,73 @@ var (
 	// WithTrustedProxies sets the list of trusted proxy IP addresses
 	WithTrustedProxies = config.WithTrustedProxies
 
+	// WithMetrics exposes Prometheus RED metrics (requests, latency, in-flight, sizes) and Go runtime
+	// metrics at the given path (e.g., "/metrics"), in Prometheus text or OpenMetrics format
+	WithMetrics = config.WithMetrics
+
+	// WithMetricsNamespace prefixes metric names (e.g., "orders" gives orders_http_requests_total)
+	WithMetricsNamespace = config.WithMetricsNamespace
+
+	// WithMetricsBuckets sets the latency histogram buckets in seconds (default: prometheus.DefBuckets)
+	WithMetricsBuckets = config.WithMetricsBuckets
+
+	// WithHealth serves the health checks of Platform.Health as JSON at the given path (e.g., "/health"),
+	// answering 503 when a check is down
+	WithHealth = config.WithHealth
+
+	// WithRoutesEndpoint exposes the route table as JSON at the given path (e.g., "/debug/routes")
+	WithRoutesEndpoint = config.WithRoutesEndpoint
+
+	// WithOpenAPI serves the generated OpenAPI 3.1 document at specPath (e.g., "/openapi.json")
+	// and the documentation UI at docsPath (e.g., "/docs"); an empty docsPath disables the UI
+	WithOpenAPI = config.WithOpenAPI
+
+	// WithOpenAPIUI selects the documentation UI: "swagger" (default) or "redoc"
+	WithOpenAPIUI = config.WithOpenAPIUI
+
+	// WithOpenAPIAssetsURL loads the UI scripts and styles from another base URL than the
+	// jsDelivr CDN (e.g., an internal mirror)
+	WithOpenAPIAssetsURL = config.WithOpenAPIAssetsURL
+
+	// WithOpenAPIAssets serves the UI scripts and styles from the platform under <docsPath>/assets
+	// Example: httpplatform.WithOpenAPIAssets(uiassets.Handler()) embeds them in the binary
+	WithOpenAPIAssets = config.WithOpenAPIAssets
+
+	// WithErrorFormat selects the error response format: ErrorFormatAPIError (default) or ErrorFormatProblem (RFC 9457)
+	WithErrorFormat = config.WithErrorFormat
+
+	// WithProblemTypeBaseURI sets the prefix of problem type URIs (e.g., "https://errors.example.com")
+	WithProblemTypeBaseURI = config.WithProblemTypeBaseURI
+
+	// WithNoRouteHandler replaces the handler of requests matching no route (default: 404 in the platform error format)
+	WithNoRouteHandler = config.WithNoRouteHandler
+
+	// WithNoMethodHandler replaces the handler of requests whose path only exists with other methods
+	// (default: 405 with an Allow header)
+	WithNoMethodHandler = config.WithNoMethodHandler
+
+	// WithAggregatedErrors makes ErrorHandler respond with every error added with c.Error (default: first error only)
+	WithAggregatedErrors = config.WithAggregatedErrors
+
+	// WithValidationLocales sets the locales of validation messages, selected from Accept-Language
+	// The first locale is the fallback. See SupportedValidationLocales.
+	WithValidationLocales = config.WithValidationLocales
+
+	// WithValidationStructFieldNames reports Go struct field names in validation errors instead of json tag names
+	WithValidationStructFieldNames = config.WithValidationStructFieldNames
+
+	// WithValidationMessages overrides built-in validation messages of a locale, per tag
+	// Example: config.WithValidationMessages("en", map[string]string{"required": "{0} cannot be empty"})
+	WithValidationMessages = config.WithValidationMessages
+
+	// WithCustomValidator registers a validation tag with its messages per locale
+	// Example: config.WithCustomValidator("sku", isSKU, map[string]string{"en": "{0} must be a valid SKU"})
+	WithCustomValidator = config.WithCustomValidator
+
+	// WithErrorMappers registers mappers converting application errors (e.g., sql.ErrNoRows) to responses
+	// Example: config.WithErrorMappers(httpplatform.MapError(sql.ErrNoRows, httpplatform.ErrorMapping{Status: 404}))
+	WithErrorMappers = config.WithErrorMappers
+
 	// WithTelemetry enables OpenTelemetry tracing with Datadog
 	// serviceName: name of the service (e.g., "guardian-auth")
 	// version: service version (e.g., "1.0.0")
@@ -76,11 +189,100 @@ var (
 	WithTelemetry = config.WithTelemetry
 
 	// WithTelemetrySampling configures trace sampling
-	// sampleAll: if true, samples all traces. If false, uses default sampling (10%)
+	// sampleAll: if true, samples all traces. If false, samples TelemetrySampleRatio (default: 10%)
 	WithTelemetrySampling = config.WithTelemetrySampling
 
+	// WithTelemetrySampleRatio samples a ratio of traces, between 0 and 1 (disables TelemetrySampleAll)
+	WithTelemetrySampleRatio = config.WithTelemetrySampleRatio
+
+	// WithTelemetrySamplingRules sets per-route sampling ratios, evaluated in order before the parent decision and ratio
+	// Example: config.WithTelemetrySamplingRules(httpplatform.SamplingRule{Route: "/health", Ratio: 0})
+	WithTelemetrySamplingRules = config.WithTelemetrySamplingRules
+
+	// WithTelemetryErrorSampling also exports unsampled traces whose spans end with an error status
+	WithTelemetryErrorSampling = config.WithTelemetryErrorSampling
+
+	// WithoutTelemetryParentSampling ignores the sampling decision of the upstream traceparent
+	WithoutTelemetryParentSampling = config.WithoutTelemetryParentSampling
+
 	// WithoutTelemetry disables telemetry (default is disabled)
 	WithoutTelemetry = config.WithoutTelemetry
+
+	// WithTelemetryMetricsInterval sets the export interval of OpenTelemetry metrics (default: 60s)
+	WithTelemetryMetricsInterval = config.WithTelemetryMetricsInterval
+
+	// WithTelemetryMetrics exports OpenTelemetry metrics with traces (default: traces only)
+	WithTelemetryMetrics = config.WithTelemetryMetrics
+
+	// WithoutTelemetryMetrics disables the export of OpenTelemetry metrics (the default)
+	WithoutTelemetryMetrics = config.WithoutTelemetryMetrics
+
+	// WithSpanHeaders records request headers on the request span as http.request.header.<name>
+	// Avoid credentials such as Authorization or Cookie.
+	WithSpanHeaders = config.WithSpanHeaders
+
+	// WithSpanQueryParams records query parameters on the request span as http.request.query.<name>
+	WithSpanQueryParams = config.WithSpanQueryParams
+
+	// WithSpanUserID records the user of each request as enduser.id, resolved after the handlers
+	// Example: httpplatform.WithSpanUserID(func(c *gin.Context) string { return c.GetString("user_id") })
+	WithSpanUserID = config.WithSpanUserID
+
+	// WithSpanTenantID records the tenant of each request as tenant.id, resolved after the handlers
+	WithSpanTenantID = config.WithSpanTenantID
+
+	// WithTelemetryResourceDetectors adds detected attributes to the telemetry resource
+	// Example: httpplatform.WithTelemetryResourceDetectors(httpplatform.ResourceDetectorHost, httpplatform.ResourceDetectorKubernetes)
+	WithTelemetryResourceDetectors = config.WithTelemetryResourceDetectors
+
+	// WithTelemetryResourceAttributes adds attributes to the telemetry resource (e.g., map[string]string{"team": "payments"})
+	WithTelemetryResourceAttributes = config.WithTelemetryResourceAttributes
+
+	// WithTelemetryLogs exports OTel log records with traces (default: traces only)
+	WithTelemetryLogs = config.WithTelemetryLogs
+
+	// WithoutTelemetryLogs disables the export of OTel log records (the default)
+	WithoutTelemetryLogs = config.WithoutTelemetryLogs
+
+	// WithTelemetryStdout prints traces, metrics and logs as JSON to standard output instead of exporting them
+	WithTelemetryStdout = config.WithTelemetryStdout
+
+	// WithTelemetryFile appends traces, metrics and logs as JSON lines to a file instead of exporting them
+	WithTelemetryFile = config.WithTelemetryFile
+
+	// WithOTLPProtocol selects the OTLP transport: OTLPProtocolHTTP (default, port 4318) or OTLPProtocolGRPC (port 4317)
+	WithOTLPProtocol = config.WithOTLPProtocol
+
+	// WithOTLPTLS connects to the collector over TLS, verified with caFile (system roots when empty)
+	// certFile and keyFile set a client certificate for mTLS (both empty to disable)
+	WithOTLPTLS = config.WithOTLPTLS
+
+	// WithOTLPHeaders adds headers sent with every export (e.g., map[string]string{"DD-API-KEY": key})
+	WithOTLPHeaders = config.WithOTLPHeaders
+
+	// WithOTLPCompression sets the export compression: "gzip" or "none" (default: none)
+	WithOTLPCompression = config.WithOTLPCompression
+
+	// WithOTLPURLPath sets a prefix of the HTTP signal paths, for collectors behind a proxy (e.g., "/otlp" gives /otlp/v1/traces)
+	WithOTLPURLPath = config.WithOTLPURLPath
+
+	// WithOTLPTimeout sets the timeout of each export (default: 10s)
+	WithOTLPTimeout = config.WithOTLPTimeout
+
+	// WithOTLPRetry sets the backoff of failed exports (default: 5s initial, 30s max interval, 1m total)
+	WithOTLPRetry = config.WithOTLPRetry
+
+	// WithoutOTLPRetry drops failed exports instead of retrying them
+	WithoutOTLPRetry = config.WithoutOTLPRetry
+
+	// WithStrictTelemetry makes New return telemetry initialization errors instead of running without telemetry,
+	// including a collector that cannot be reached at startup
+	WithStrictTelemetry = config.WithStrictTelemetry
+
+	// WithTelemetryInitRetry keeps retrying telemetry initialization in the background while the collector
+	// is unreachable, doubling the delay from initialBackoff up to maxBackoff (zero values use 1s and 1m).
+	// Configuration errors are not retried: the platform logs them and runs without telemetry.
+	WithTelemetryInitRetry = config.WithTelemetryInitRetry
 )
 
 // Error functions from errors package
@@ -109,6 +311,9 @@ var (
 	// ErrNotStarted returns an error when attempting to stop a platform that is not running
 	ErrNotStarted = errors.ErrNotStarted
 
+	// ErrRouteConflicts returns an error listing routes that could not be registered
+	ErrRouteConflicts = errors.ErrRouteConflicts
+
 	// HTTP Domain Errors
 
 	// NewNotFoundError creates a 404 Not Found error with a custom message
@@ -132,6 +337,15 @@ var (
 	// NewTooManyRequestsError creates a 429 Too Many Requests error with a custom message (rate limiting)
 	NewTooManyRequestsError = errors.NewTooManyRequestsError
 
+	// NewMethodNotAllowedError creates a 405 Method Not Allowed error with a custom message (use WithAllowHeader)
+	NewMethodNotAllowedError = errors.NewMethodNotAllowedError
+
+	// NewNotAcceptableError creates a 406 Not Acceptable error with a custom message (unsupported Accept header)
+	NewNotAcceptableError = errors.NewNotAcceptableError
+
+	// NewUnsupportedMediaTypeError creates a 415 Unsupported Media Type error with a custom message (unsupported Content-Type)
+	NewUnsupportedMediaTypeError = errors.NewUnsupportedMediaTypeError
+
 	// NewInternalServerError creates a 500 Internal Server Error with a custom message
 	NewInternalServerError = errors.NewInternalServerError
 
@@ -140,6 +354,50 @@ var (
 
 	// NewExternalServiceError creates an error for external service failures with a custom status code
 	NewExternalServiceError = errors.NewExternalServiceError
+
+	// HTTP Domain Error Options
+
+	// WithErrorCode sets a stable machine-readable code returned to clients (e.g., "ORDER_NOT_FOUND")
+	WithErrorCode = errors.WithErrorCode
+
+	// WithErrorDetail adds a metadata entry returned to clients
+	WithErrorDetail = errors.WithErrorDetail
+
+	// WithErrorDetails adds metadata entries returned to clients
+	WithErrorDetails = errors.WithErrorDetails
+
+	// WithErrorCause sets the underlying error (logged and available through errors.Unwrap, never sent to clients)
+	WithErrorCause = errors.WithErrorCause
+
+	// WithInternalMessage sets a message used in logs instead of the public message
+	WithInternalMessage = errors.WithInternalMessage
+
+	// WithErrorHeader adds a response header written alongside the error body
+	WithErrorHeader = errors.WithErrorHeader
+
+	// WithRetryAfter sets the Retry-After header to a delay in seconds (429, 503)
+	// Example: httpplatform.NewTooManyRequestsError("Rate limit exceeded", httpplatform.WithRetryAfter(30*time.Second))
+	WithRetryAfter = errors.WithRetryAfter
+
+	// WithRetryAfterDate sets the Retry-After header to a date (e.g., the end of a maintenance window)
+	WithRetryAfterDate = errors.WithRetryAfterDate
+
+	// WithAuthenticateChallenge adds a WWW-Authenticate challenge (401)
+	// Example: httpplatform.NewUnauthorizedError("Token expired", httpplatform.WithAuthenticateChallenge(`Bearer error="invalid_token"`))
+	WithAuthenticateChallenge = errors.WithAuthenticateChallenge
+
+	// WithAllowHeader sets the Allow header listing the methods supported by the resource (405)
+	WithAllowHeader = errors.WithAllowHeader
+)
+
+// Error types from errors package
+type (
+	// HTTPError is implemented by every HTTP domain error (status, code, details, public message, headers).
+	// Use errors.As to inspect domain errors wrapped with fmt.Errorf("...: %w", err).
+	HTTPError = errors.HTTPError
+
+	// ErrorOption configures an HTTP domain error (code, details, cause, internal message).
+	ErrorOption = errors.ErrorOption
 )
 
 // Middleware functions
@@ -157,8 +415,112 @@ var (
 	ContextCancellation = middleware.ContextCancellation
 
 	// WithTimeout creates a middleware that enforces a timeout for specific endpoints.
+	// The response is buffered so exactly one response is sent, and handler panics reach ErrorHandler.
+	// Timeouts and the work a timed-out handler did are logged with the platform logger.
 	// Example: router.GET("/slow", httpplatform.WithTimeout(5*time.Second), handler)
 	WithTimeout = middleware.WithTimeout
+
+	// WithProblemDetails makes ErrorHandler render RFC 9457 problem details (application/problem+json).
+	// Example: platform.Use(httpplatform.ErrorHandler(logger, httpplatform.WithProblemDetails("https://errors.example.com")))
+	WithProblemDetails = middleware.WithProblemDetails
+
+	// WithErrorAggregation makes ErrorHandler log and respond with every error added with c.Error.
+	// The most severe error sets the status; all errors are listed in cause.
+	WithErrorAggregation = middleware.WithErrorAggregation
+
+	// WithErrorMapper makes ErrorHandler map application errors to responses before the built-in handling.
+	// Example: httpplatform.ErrorHandler(logger, httpplatform.WithErrorMapper(httpplatform.MapError(sql.ErrNoRows, mapping)))
+	WithErrorMapper = middleware.WithErrorMapper
+
+	// ConfigureValidation sets up translated validation messages on gin's validator.
+	// New calls it with the Config settings; call it directly when using ErrorHandler without the platform.
+	ConfigureValidation = middleware.ConfigureValidation
+
+	// SupportedValidationLocales returns the locales with built-in validation messages.
+	SupportedValidationLocales = middleware.SupportedValidationLocales
+
+	// MapError creates an ErrorMapper for errors matching a target with errors.Is (e.g., sql.ErrNoRows).
+	MapError = middleware.MapError
+
+	// OpenAPIValidation creates a middleware that validates requests (and optionally responses)
+	// against an OpenAPI 3.0/3.1 document. Mismatches are reported by ErrorHandler with the same
+	// {"field", "reason"} cause format as binding validation errors.
+	// Example: validation, err := httpplatform.OpenAPIValidation(spec, httpplatform.OpenAPIValidationConfig{})
+	OpenAPIValidation = middleware.OpenAPIValidation
+
+	// LoadOpenAPISpec loads and validates an OpenAPI document (JSON or YAML) from a file.
+	LoadOpenAPISpec = middleware.LoadOpenAPISpec
+)
+
+// Content negotiation types from middleware package
+type (
+	// ErrorHandlerOption configures ErrorHandler.
+	ErrorHandlerOption = middleware.ErrorHandlerOption
+
+	// ProblemDetails is the RFC 9457 error body rendered in problem mode.
+	ProblemDetails = middleware.ProblemDetails
+
+	// ErrorMapper converts application errors to responses (see WithErrorMapper).
+	ErrorMapper = middleware.ErrorMapper
+
+	// ErrorMapping describes the response of a mapped error (status, message, code, details, cause, log level).
+	ErrorMapping = middleware.ErrorMapping
+
+	// LogLevel selects how a mapped error is logged.
+	LogLevel = middleware.LogLevel
+
+	// ValidationConfig configures translated validation messages (see ConfigureValidation).
+	ValidationConfig = middleware.ValidationConfig
+
+	// CustomValidator is an application validation tag with its messages per locale.
+	CustomValidator = middleware.CustomValidator
+
+	// Renderer encodes response values in a specific format (see RegisterRenderer).
+	Renderer = middleware.Renderer
+
+	// RendererRegistry maps media types to renderers and negotiates them from Accept headers.
+	RendererRegistry = middleware.RendererRegistry
+)
+
+// Log levels of mapped errors (LogLevelDefault logs 5xx as Error and others as Warn)
+const (
+	LogLevelDefault = middleware.LogLevelDefault
+	LogLevelDebug   = middleware.LogLevelDebug
+	LogLevelInfo    = middleware.LogLevelInfo
+	LogLevelWarn    = middleware.LogLevelWarn
+	LogLevelError   = middleware.LogLevelError
+)
+
+// MapErrorAs creates an ErrorMapper for errors of type T found with errors.As (e.g., *pq.Error)
+// fn builds the mapping from the matched error and may reject it by returning false.
+func MapErrorAs[T error](fn func(err T) (ErrorMapping, bool)) ErrorMapper {
+	return middleware.MapErrorAs(fn)
+}
+
+// Content negotiation
+var (
+	// Render writes a response in the format negotiated from the Accept header
+	// (JSON, XML, MessagePack, Protobuf, CBOR or registered formats); 406 when none is acceptable.
+	Render = middleware.Render
+
+	// NegotiateRenderer picks the renderer for the request Accept header.
+	NegotiateRenderer = middleware.NegotiateRenderer
+
+	// RegisterRenderer adds or replaces the renderer of a media type in the default registry,
+	// copied by platforms created afterwards (see Platform.Renderers).
+	RegisterRenderer = middleware.RegisterRenderer
+
+	// NewRenderer creates a Renderer from a Content-Type and an encode function.
+	NewRenderer = middleware.NewRenderer
+)
+
+// OpenAPI validation types from middleware package
+type (
+	// OpenAPIValidationConfig configures OpenAPIValidation (base path, unknown routes, response validation).
+	OpenAPIValidationConfig = middleware.OpenAPIValidationConfig
+
+	// OpenAPIValidationError is reported when a request or response does not match the OpenAPI document.
+	OpenAPIValidationError = middleware.OpenAPIValidationError
 )
 
 // Context helper functions for checking request cancellation in handlers
@@ -173,6 +535,18 @@ var (
 	GetContextError = middleware.GetContextError
 )
 
+// Trace helper functions for correlating logs with traces
+var (
+	// GetTraceID returns the W3C trace ID of the request (the OpenTelemetry trace ID when telemetry is enabled).
+	GetTraceID = middleware.GetTraceID
+
+	// GetSpanID returns the span ID of the request.
+	GetSpanID = middleware.GetSpanID
+
+	// TraceLogFields returns trace_id and span_id, plus Datadog's decimal dd.trace_id and dd.span_id, as log fields.
+	TraceLogFields = middleware.TraceLogFields
+)
+
 // Logger interface and Fields type from middleware package
 // This allows users to implement custom loggers without importing middleware directly
 type (
@@ -182,4 +556,130 @@ type (
 
 	// Fields represents a map of structured log fields for adding metadata to log entries.
 	Fields = middleware.Fields
+
+	// OTelLogger is a Logger emitting OpenTelemetry log records correlated with traces.
+	OTelLogger = middleware.OTelLogger
+
+	// OTelLoggerConfig configures NewOTelLogger (scope name, provider, tee logger).
+	OTelLoggerConfig = middleware.OTelLoggerConfig
+)
+
+// NewOTelLogger creates a Logger emitting OpenTelemetry log records, exported by the platform
+// when telemetry is enabled. Set Tee to keep sending logs to an existing logger while migrating.
+var NewOTelLogger = middleware.NewOTelLogger
+
+// Outbound HTTP client types from httpclient package
+type (
+	// HTTPClient calls a downstream service with trace propagation, client spans, retries and a circuit breaker.
+	HTTPClient = httpclient.Client
+
+	// HTTPClientConfig configures NewHTTPClient (service name, attempt timeout, transport, retry, breaker, status passthrough).
+	HTTPClientConfig = httpclient.Config
+
+	// HTTPRetryConfig configures the retry of idempotent calls (attempts, jittered backoff, statuses).
+	HTTPRetryConfig = httpclient.RetryConfig
+
+	// CircuitBreakerConfig configures the circuit breaker of an HTTPClient (failure threshold, open timeout).
+	CircuitBreakerConfig = httpclient.BreakerConfig
+
+	// HTTPStatusError is the cause of the ExternalServiceError returned for non-2xx responses (errors.As).
+	HTTPStatusError = httpclient.StatusError
+)
+
+// NewHTTPClient creates a client for a downstream service; create one per service and pass the
+// request context to its calls: client.Get(c.Request.Context(), url)
+var NewHTTPClient = httpclient.New
+
+// ErrCircuitOpen is the cause of the ExternalServiceError returned while the circuit is open (errors.Is).
+var ErrCircuitOpen = httpclient.ErrCircuitOpen
+
+// Route option types from middleware package
+type (
+	// RouteOption configures a route registered with Handle.
+	RouteOption = middleware.RouteOption
+
+	// RouteConfig holds the per-route settings; read it in middleware with GetRouteConfig.
+	RouteConfig = middleware.RouteConfig
+)
+
+// Route provides the route option constructors for Handle:
+// Route.Timeout(d), Route.MaxBody(bytes), Route.NoLog(), Route.Tags(tags...)
+var Route = middleware.Route
+
+// Route helpers
+var (
+	// GetRouteConfig returns the options of the matched route, or nil if it was registered without options.
+	GetRouteConfig = middleware.GetRouteConfig
+
+	// GetLogger returns the platform logger of the request (Config.Logger), or nil outside ErrorHandler.
+	GetLogger = middleware.GetLogger
+
+	// NotFoundHandler is the default handler of unmatched routes (404 NotFoundError).
+	NotFoundHandler = middleware.NotFoundHandler
+
+	// MethodNotAllowedHandler is the default handler of wrong methods (405 with an Allow header).
+	MethodNotAllowedHandler = middleware.MethodNotAllowedHandler
+
+	// MaxBodySize creates a middleware that limits the request body size (413 when exceeded).
+	MaxBodySize = middleware.MaxBodySize
+)
+
+// Metrics types from middleware package
+type (
+	// Metrics records Prometheus RED metrics per route; see Platform.Metrics.
+	Metrics = middleware.Metrics
+
+	// MetricsConfig configures NewMetrics (namespace, histogram buckets).
+	MetricsConfig = middleware.MetricsConfig
+)
+
+// NewMetrics creates Prometheus metrics for use without the platform:
+// router.Use(m.Handler()); router.GET("/metrics", m.Endpoint())
+var NewMetrics = middleware.NewMetrics
+
+// Health types from middleware package
+type (
+	// Health is a registry of health checks; see Platform.Health.
+	Health = middleware.Health
+
+	// HealthCheck checks a dependency or subsystem.
+	HealthCheck = middleware.HealthCheck
+
+	// HealthResult is the result of a health check (status, error, details).
+	HealthResult = middleware.HealthResult
+
+	// HealthReport is the aggregated result of every health check.
+	HealthReport = middleware.HealthReport
+
+	// HealthStatus is the status of a health check: HealthUp, HealthDegraded or HealthDown.
+	HealthStatus = middleware.HealthStatus
+)
+
+// Health statuses; the health endpoint answers 503 only when a check is down
+const (
+	HealthUp       = middleware.HealthUp
+	HealthDegraded = middleware.HealthDegraded
+	HealthDown     = middleware.HealthDown
+)
+
+// NewHealth creates a health check registry for use without the platform:
+// router.GET("/health", h.Endpoint())
+var NewHealth = middleware.NewHealth
+
+// CORS types from middleware package
+type (
+	// CORSConfig configures a CORS policy, e.g. for a route group override via group.CORS(cfg).
+	CORSConfig = middleware.CORSConfig
+
+	// OriginPolicy is a concurrency-safe set of allowed origins that can be reloaded at runtime.
+	OriginPolicy = middleware.OriginPolicy
+)
+
+// CORS helpers
+var (
+	// NewOriginPolicy compiles origin patterns (exact, "*", "https://*.example.com", "regex:<expr>").
+	NewOriginPolicy = middleware.NewOriginPolicy
+
+	// ValidateOrigins checks that every origin pattern is well formed.
+	ValidateOrigins = middleware.ValidateOrigins
 )
@@ -0,0 +1,137 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerConfig configures the circuit breaker of a client
+// After FailureThreshold consecutive failed attempts (transport errors or 5xx responses), the
+// circuit opens and calls fail immediately for OpenTimeout; then a single trial call is let
+// through, closing the circuit on success or opening it again on failure.
+type BreakerConfig struct {
+	Disabled         bool          // Never open the circuit
+	FailureThreshold int           // Consecutive failures opening the circuit (default: 5)
+	OpenTimeout      time.Duration // Time the circuit stays open before a trial call (default: 30s)
+}
+
+// withDefaults fills the unset fields
+func (b BreakerConfig) withDefaults() BreakerConfig {
+	if b.FailureThreshold <= 0 {
+		b.FailureThreshold = 5
+	}
+	if b.OpenTimeout <= 0 {
+		b.OpenTimeout = 30 * time.Second
+	}
+	return b
+}
+
+// Circuit breaker states
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half-open"
+)
+
+// breakerOutcome is the result of an attempt as seen by the circuit breaker
+type breakerOutcome int
+
+const (
+	outcomeSuccess breakerOutcome = iota
+	outcomeFailure
+	outcomeIgnored // Cancelled by the caller: says nothing about the service
+)
+
+// breaker is a consecutive-failures circuit breaker
+type breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	current  string
+	failures int
+	openedAt time.Time
+	trial    bool // A trial call is in flight (half-open)
+}
+
+// newBreaker creates a closed circuit breaker
+func newBreaker(cfg BreakerConfig) *breaker {
+	return &breaker{cfg: cfg, current: circuitClosed}
+}
+
+// allow reports whether a call may proceed, or how long the circuit stays open
+func (b *breaker) allow() (bool, time.Duration) {
+	if b == nil {
+		return true, 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current {
+	case circuitOpen:
+		if wait := b.cfg.OpenTimeout - time.Since(b.openedAt); wait > 0 {
+			return false, wait
+		}
+		b.current = circuitHalfOpen
+		b.trial = true
+		return true, 0
+	case circuitHalfOpen:
+		if b.trial {
+			return false, b.cfg.OpenTimeout
+		}
+		b.trial = true
+		return true, 0
+	default:
+		return true, 0
+	}
+}
+
+// record updates the circuit with the outcome of an allowed call
+func (b *breaker) record(outcome breakerOutcome) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.current == circuitHalfOpen {
+		b.trial = false
+		switch outcome {
+		case outcomeSuccess:
+			b.current = circuitClosed
+			b.failures = 0
+		case outcomeFailure:
+			b.open()
+		}
+		return
+	}
+
+	switch outcome {
+	case outcomeSuccess:
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if b.current == circuitClosed && b.failures >= b.cfg.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+// open opens the circuit; the caller holds the lock
+func (b *breaker) open() {
+	b.current = circuitOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+// state returns the current state
+func (b *breaker) state() string {
+	if b == nil {
+		return circuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.current == circuitOpen && time.Since(b.openedAt) >= b.cfg.OpenTimeout {
+		return circuitHalfOpen
+	}
+	return b.current
+}
@@ -0,0 +1,337 @@
+// Package httpclient provides an instrumented HTTP client for calls to downstream services
+// Requests propagate the trace context (traceparent and X-Trace-Id) and create client spans;
+// attempts are bounded by the deadline of the inbound request, idempotent calls are retried
+// with jittered backoff, a circuit breaker stops calling a failing service, and non-2xx
+// responses are returned as ExternalServiceError (502, or 503/504 for the same upstream status).
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDHeader carries the trace ID to downstream services (see middleware.TraceIDHeader)
+const TraceIDHeader = "X-Trace-Id"
+
+// maxErrorBody bounds the response body kept in StatusError
+const maxErrorBody = 4 << 10
+
+// Config configures a Client
+type Config struct {
+	// Name is the downstream service, recorded as peer.service on client spans and used in
+	// error messages (e.g., "payments")
+	Name string
+
+	// Timeout bounds each attempt; attempts never outlive the deadline of the request context
+	// (default: 10s)
+	Timeout time.Duration
+
+	// Transport is the underlying transport (default: http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// PassthroughStatus answers non-2xx responses with the upstream status (e.g., 404 → 404)
+	// By default the service answers 502, or 503 and 504 for the same upstream statuses: an
+	// upstream 401 or 404 says nothing about the request the client sent to this service.
+	PassthroughStatus bool
+
+	Retry   RetryConfig
+	Breaker BreakerConfig
+}
+
+// Client calls a downstream service over HTTP
+// It is safe for concurrent use; create one Client per downstream service, so each has its
+// own circuit breaker.
+type Client struct {
+	cfg     Config
+	client  *http.Client
+	breaker *breaker // nil when the circuit breaker is disabled
+}
+
+// ErrCircuitOpen is the cause of the ExternalServiceError returned while the circuit is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// StatusError is the cause of the ExternalServiceError returned for non-2xx responses
+// Use errors.As to read the upstream response, e.g. to forward validation errors.
+type StatusError struct {
+	Service    string
+	Method     string
+	URL        string // Request URL without query string
+	StatusCode int
+	Header     http.Header
+	Body       []byte // First 4 KiB of the response body
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s %s: %d %s", e.Service, e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// New creates a client with the given configuration
+// Zero values use the defaults documented on Config, RetryConfig and BreakerConfig.
+func New(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+	cfg.Retry = cfg.Retry.withDefaults()
+
+	var opts []otelhttp.Option
+	if cfg.Name != "" {
+		opts = append(opts, otelhttp.WithSpanOptions(trace.WithAttributes(semconv.PeerService(cfg.Name))))
+	}
+
+	c := &Client{
+		cfg:    cfg,
+		client: &http.Client{Transport: otelhttp.NewTransport(cfg.Transport, opts...)},
+	}
+	if !cfg.Breaker.Disabled {
+		c.breaker = newBreaker(cfg.Breaker.withDefaults())
+	}
+	return c
+}
+
+// Get sends a GET request
+// Pass the request context (c.Request.Context() in handlers) so the call is traced and bounded.
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Post sends a POST request (not retried unless it carries an Idempotency-Key header)
+func (c *Client) Post(ctx context.Context, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.Do(req)
+}
+
+// Do sends the request, retrying idempotent calls, and returns the 2xx response
+// Other outcomes are returned as errors the ErrorHandler understands:
+//   - non-2xx responses: ExternalServiceError 502, 503 or 504, see Config.PassthroughStatus (cause: *StatusError)
+//   - open circuit: ExternalServiceError 503 with Retry-After (cause: ErrCircuitOpen)
+//   - attempt timeouts and expired request deadline: ExternalServiceError 504
+//   - other transport errors: ExternalServiceError 502
+//   - cancelled request context: the context error
+//
+// The caller must close the body of the returned response.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attempts := 1
+	if retryable(req) {
+		attempts = c.cfg.Retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if ok, wait := c.breaker.allow(); !ok {
+			return nil, c.circuitOpenError(wait)
+		}
+
+		resp, err := c.attempt(req, attempt)
+		c.breaker.record(c.outcome(ctx, resp, err))
+
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			closeBody(resp)
+			return nil, c.contextError(ctx)
+		}
+
+		delay, retry := c.retryDelay(resp, err, attempt)
+		if !retry || attempt >= attempts || !withinDeadline(ctx, delay) {
+			return nil, c.responseError(req, resp, err)
+		}
+		closeBody(resp)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, c.contextError(ctx)
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends one attempt of the request with its own timeout and the trace headers
+func (c *Client) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), c.cfg.Timeout)
+
+	r := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	// The telemetry propagator replaces traceparent with the client span; without telemetry
+	// the request keeps the trace of the inbound request
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(r.Header))
+		r.Header.Set(TraceIDHeader, sc.TraceID().String())
+	}
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The attempt context lives until the caller closes the body
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// outcome classifies an attempt for the circuit breaker: transport errors and 5xx responses
+// are failures, cancellations by the caller are ignored
+func (c *Client) outcome(ctx context.Context, resp *http.Response, err error) breakerOutcome {
+	switch {
+	case ctx.Err() != nil:
+		return outcomeIgnored
+	case err != nil:
+		return outcomeFailure
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
+
+// responseError converts a failed call to an ExternalServiceError
+func (c *Client) responseError(req *http.Request, resp *http.Response, err error) error {
+	if err != nil {
+		// Query strings and credentials may hold secrets: keep them out of logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = &url.Error{Op: urlErr.Op, URL: redactURL(req.URL), Err: urlErr.Err}
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return platformErrors.NewExternalServiceError("External service timeout", http.StatusGatewayTimeout,
+				platformErrors.WithInternalMessage(c.name()+" request timed out"),
+				platformErrors.WithErrorCause(err),
+			)
+		}
+		return platformErrors.NewExternalServiceError("External service unavailable", http.StatusBadGateway,
+			platformErrors.WithInternalMessage(c.name()+" request failed"),
+			platformErrors.WithErrorCause(err),
+		)
+	}
+
+	defer closeBody(resp)
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	statusErr := &StatusError{
+		Service:    c.name(),
+		Method:     req.Method,
+		URL:        redactURL(req.URL),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	opts := []platformErrors.ErrorOption{
+		platformErrors.WithInternalMessage(c.name() + " request failed"),
+		platformErrors.WithErrorCause(statusErr),
+	}
+
+	status := http.StatusBadGateway
+	switch {
+	case c.cfg.PassthroughStatus:
+		status = resp.StatusCode
+	case resp.StatusCode == http.StatusGatewayTimeout:
+		status = http.StatusGatewayTimeout
+	case resp.StatusCode == http.StatusServiceUnavailable:
+		status = http.StatusServiceUnavailable
+	}
+	// Clients told to come back later wait as long as the upstream asked
+	if status == http.StatusServiceUnavailable || status == http.StatusTooManyRequests {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			opts = append(opts, platformErrors.WithRetryAfter(retryAfter))
+		}
+	}
+	return platformErrors.NewExternalServiceError("External service request failed", status, opts...)
+}
+
+// contextError converts the end of the request context: an expired deadline means the service
+// did not answer in time (504), a cancellation is returned as the context error
+func (c *Client) contextError(ctx context.Context) error {
+	err := fmt.Errorf("%s: %w", c.name(), ctx.Err())
+	if errors.Is(err, context.DeadlineExceeded) {
+		return platformErrors.NewExternalServiceError("External service timeout", http.StatusGatewayTimeout,
+			platformErrors.WithInternalMessage(c.name()+" request timed out"),
+			platformErrors.WithErrorCause(err),
+		)
+	}
+	return err
+}
+
+// circuitOpenError is returned without calling the service while the circuit is open
+func (c *Client) circuitOpenError(wait time.Duration) error {
+	return platformErrors.NewExternalServiceError("External service unavailable", http.StatusServiceUnavailable,
+		platformErrors.WithInternalMessage(c.name()+" circuit open"),
+		platformErrors.WithErrorCause(ErrCircuitOpen),
+		platformErrors.WithRetryAfter(wait),
+	)
+}
+
+// CircuitState returns the state of the circuit breaker: "closed", "open" or "half-open"
+// ("closed" when the breaker is disabled)
+func (c *Client) CircuitState() string {
+	return c.breaker.state()
+}
+
+// name returns the service name used in errors
+func (c *Client) name() string {
+	if c.cfg.Name == "" {
+		return "downstream service"
+	}
+	return c.cfg.Name
+}
+
+// redactURL returns the URL without query string and credentials
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = ""
+	redacted.User = nil
+	return redacted.String()
+}
+
+// cancelBody cancels the attempt context when the response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// closeBody drains (up to a limit, so the connection can be reused) and closes a response body
+func closeBody(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+)
+
+func TestDoMapsUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		upstream    int
+		passthrough bool
+		want        int
+	}{
+		{"client error", http.StatusNotFound, false, http.StatusBadGateway},
+		{"unauthorized", http.StatusUnauthorized, false, http.StatusBadGateway},
+		{"server error", http.StatusInternalServerError, false, http.StatusBadGateway},
+		{"unavailable", http.StatusServiceUnavailable, false, http.StatusServiceUnavailable},
+		{"timeout", http.StatusGatewayTimeout, false, http.StatusGatewayTimeout},
+		{"passthrough", http.StatusNotFound, true, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "7")
+				http.Error(w, "upstream body", tt.upstream)
+			}))
+			defer server.Close()
+
+			client := New(Config{Name: "payments", PassthroughStatus: tt.passthrough, Retry: RetryConfig{MaxAttempts: 1}})
+			_, err := client.Get(context.Background(), server.URL+"/payments?token=secret")
+
+			var extErr *platformErrors.ExternalServiceError
+			if !errors.As(err, &extErr) {
+				t.Fatalf("err = %v, want ExternalServiceError", err)
+			}
+			if extErr.Status() != tt.want {
+				t.Errorf("status = %d, want %d", extErr.Status(), tt.want)
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.upstream {
+				t.Fatalf("cause = %v, want StatusError with upstream status %d", errors.Unwrap(err), tt.upstream)
+			}
+			if string(statusErr.Body) != "upstream body\n" || statusErr.URL != server.URL+"/payments" {
+				t.Errorf("StatusError = %+v", statusErr)
+			}
+			wantRetryAfter := ""
+			if tt.want == http.StatusServiceUnavailable {
+				wantRetryAfter = "7"
+			}
+			if got := extErr.Headers().Get("Retry-After"); got != wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestDoRequestDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client := New(Config{Name: "payments"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, server.URL)
+
+	var extErr *platformErrors.ExternalServiceError
+	if !errors.As(err, &extErr) || extErr.Status() != http.StatusGatewayTimeout {
+		t.Fatalf("err = %v, want ExternalServiceError 504", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = client.Get(ctx, server.URL)
+	if !errors.Is(err, context.Canceled) || errors.As(err, &extErr) {
+		t.Errorf("err = %v, want the context.Canceled error", err)
+	}
+}
@@ -0,0 +1,108 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryConfig configures the retry of idempotent calls
+// Calls are idempotent when their method is GET, HEAD, OPTIONS, TRACE, PUT or DELETE, or when
+// they carry an Idempotency-Key header; their body must be replayable (http.NewRequest sets
+// GetBody for bytes, strings and bytes.Buffer bodies).
+type RetryConfig struct {
+	MaxAttempts    int           // Attempts including the first one (default: 3, 1 disables retries)
+	InitialBackoff time.Duration // Base delay before the first retry, doubled for each retry (default: 100ms)
+	MaxBackoff     time.Duration // Maximum delay, including Retry-After delays (default: 2s)
+	Statuses       []int         // Retried response statuses (default: 429, 502, 503, 504)
+}
+
+// withDefaults fills the unset fields
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 3
+	}
+	if r.InitialBackoff <= 0 {
+		r.InitialBackoff = 100 * time.Millisecond
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = 2 * time.Second
+	}
+	if r.Statuses == nil {
+		r.Statuses = []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		}
+	}
+	return r
+}
+
+// idempotentMethods can be sent several times with the same effect
+var idempotentMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete,
+}
+
+// retryable reports whether the request may be sent again
+func retryable(req *http.Request) bool {
+	if !slices.Contains(idempotentMethods, req.Method) &&
+		req.Header.Get("Idempotency-Key") == "" && req.Header.Get("X-Idempotency-Key") == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryDelay returns the delay before the next attempt, and whether the outcome is retried
+// Transport errors and the configured statuses are retried; Retry-After is honored unless it
+// exceeds MaxBackoff.
+func (c *Client) retryDelay(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err == nil && !slices.Contains(c.cfg.Retry.Statuses, resp.StatusCode) {
+		return 0, false
+	}
+
+	delay := backoff(c.cfg.Retry, attempt)
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if retryAfter > c.cfg.Retry.MaxBackoff {
+				return 0, false
+			}
+			delay = max(delay, retryAfter)
+		}
+	}
+	return delay, true
+}
+
+// backoff returns the jittered delay after the given attempt: a random duration between half
+// and all of InitialBackoff doubled per attempt, capped at MaxBackoff
+func backoff(cfg RetryConfig, attempt int) time.Duration {
+	ceiling := cfg.MaxBackoff
+	if shift := attempt - 1; shift < 32 {
+		ceiling = min(cfg.InitialBackoff<<shift, cfg.MaxBackoff)
+	}
+	half := ceiling / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or HTTP-date format
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// withinDeadline reports whether the context leaves time for another attempt after delay
+func withinDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
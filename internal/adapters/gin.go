@@ -1,22 +1,39 @@
 package adapters
 
 import (
+	"cmp"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/edaniel30/http-platform-go/middleware"
 	config "github.com/edaniel30/http-platform-go/models"
+	"github.com/edaniel30/http-platform-go/openapi"
 	"github.com/gin-gonic/gin"
 )
 
 // GinRouter wraps gin.Engine to implement the Router interface
 type GinRouter struct {
 	engine    *gin.Engine
-	baseGroup *gin.RouterGroup // Optional base group when BasePath is configured
+	baseGroup *gin.RouterGroup             // Optional base group when BasePath is configured
+	cors      *middleware.CORSMiddleware   // Global CORS middleware, nil when CORS is disabled
+	metrics   *middleware.Metrics          // Prometheus metrics, nil when metrics are disabled
+	health    *middleware.Health           // Health checks, served at HealthPath when set
+	renderers *middleware.RendererRegistry // Response formats, copied from the default registry
+	routes    *middleware.RouteRegistry    // Per-route configuration registered through Handle
+	apiInfo   openapi.Info                 // Title and version of the generated OpenAPI document
+	apiOpts   []openapi.Option             // Generator options matching the error format
+
+	mu        sync.RWMutex
+	table     []RouteInfo // Every registered route, in registration order
+	conflicts []string    // Registration errors reported by ValidateRoutes
 }
 
 // GinRouterGroup wraps gin.RouterGroup to implement the RouterGroup interface
 type GinRouterGroup struct {
-	group *gin.RouterGroup
+	group  *gin.RouterGroup
+	router *GinRouter
 }
 
 // NewGinRouter creates a new Gin router with the given configuration
@@ -32,8 +49,45 @@ func NewGinRouter(cfg config.Config) *GinRouter {
 		engine.SetTrustedProxies(cfg.TrustedProxies)
 	}
 
+	router := &GinRouter{
+		engine: engine,
+		routes: middleware.NewRouteRegistry(),
+		health: middleware.NewHealth(),
+
+		// Formats registered on one platform do not leak into the others
+		renderers: middleware.Renderers.Clone(),
+		apiInfo: openapi.Info{
+			Title:   cfg.ServiceName,
+			Version: cfg.ServiceVersion,
+		},
+	}
+	if cfg.ErrorFormat == config.ErrorFormatProblem {
+		router.apiOpts = append(router.apiOpts, openapi.WithProblemDetails())
+	}
+
 	// Apply middleware to engine first
-	// Order matters: TraceID -> ErrorHandler -> ContextCancellation -> CORS -> Telemetry -> Logger
+	// Order matters: RouteMetadata -> Metrics -> Telemetry -> TraceID -> ErrorHandler -> ContextCancellation -> CORS -> Logger
+
+	// 0. RouteMetadata - exposes per-route configuration to every middleware below
+	engine.Use(middleware.RouteMetadata(router.routes))
+
+	// Metrics - before ErrorHandler, so error responses and recovered panics are recorded with their status
+	if cfg.MetricsPath != "" {
+		router.metrics = middleware.NewMetrics(middleware.MetricsConfig{
+			Namespace:       cfg.MetricsNamespace,
+			DurationBuckets: cfg.MetricsBuckets,
+		})
+		engine.Use(router.metrics.Handler())
+	}
+
+	// Telemetry - starts the request span, so TraceID reports its trace ID and ErrorHandler
+	// responses are recorded with their status
+	if cfg.EnableTelemetry {
+		engine.Use(middleware.Telemetry(cfg.ServiceName), middleware.TelemetryErrorFilter())
+		if spanAttrs := cfg.SpanAttributesConfig(); !spanAttrs.IsZero() {
+			engine.Use(middleware.SpanAttributes(spanAttrs))
+		}
+	}
 
 	// 1. TraceID - for traceability across the entire pipeline
 	if cfg.EnableTraceID {
@@ -42,7 +96,7 @@ func NewGinRouter(cfg config.Config) *GinRouter {
 
 	// 2. ErrorHandler - must be early to catch panics from other middleware
 	// This replaces the old Recovery middleware and handles all errors
-	engine.Use(middleware.ErrorHandler(cfg.Logger))
+	engine.Use(middleware.ErrorHandler(cfg.Logger, append(cfg.ErrorHandlerOptions(), middleware.WithRenderers(router.renderers))...))
 
 	// 3. ContextCancellation - detect client disconnections early to avoid wasted work
 	if cfg.EnableContextCancellation {
@@ -51,28 +105,59 @@ func NewGinRouter(cfg config.Config) *GinRouter {
 
 	// 4. CORS - handle CORS before processing requests
 	if cfg.EnableCORS {
-		corsMiddleware := middleware.CORS(middleware.CORSConfig{
+		router.cors = middleware.NewCORS(middleware.CORSConfig{
 			AllowedOrigins:   cfg.AllowedOrigins,
 			AllowedMethods:   cfg.AllowedMethods,
 			AllowedHeaders:   cfg.AllowedHeaders,
 			ExposedHeaders:   cfg.ExposedHeaders,
 			AllowCredentials: cfg.AllowCredentials,
 			MaxAge:           cfg.MaxAge,
+			AllowOriginFunc:  cfg.AllowOriginFunc,
 		})
-		engine.Use(corsMiddleware)
+		engine.Use(router.cors.Handler())
 	}
 
-	// 5. Telemetry middleware (traces all HTTP requests)
-	if cfg.EnableTelemetry {
-		engine.Use(middleware.Telemetry(cfg.ServiceName))
-	}
-
-	// 6. Logger - log after all processing
+	// 5. Logger - log after all processing
 	if cfg.EnableLogger {
 		engine.Use(middleware.BasicLogger(cfg.Logger))
 	}
 
-	router := &GinRouter{engine: engine}
+	// Debug and documentation endpoints (registered on the engine, outside BasePath)
+	hidden := []middleware.RouteOption{middleware.Route.Hidden(), middleware.Route.NoLog()}
+	if cfg.RoutesEndpoint != "" {
+		router.handle(&engine.RouterGroup, http.MethodGet, cfg.RoutesEndpoint, router.routesHandler, hidden)
+	}
+	if cfg.MetricsPath != "" {
+		router.handle(&engine.RouterGroup, http.MethodGet, cfg.MetricsPath, router.metrics.Endpoint(), hidden)
+	}
+	if cfg.HealthPath != "" {
+		router.handle(&engine.RouterGroup, http.MethodGet, cfg.HealthPath, router.health.Endpoint(), hidden)
+	}
+	if cfg.OpenAPIPath != "" {
+		router.handle(&engine.RouterGroup, http.MethodGet, cfg.OpenAPIPath, router.openAPIHandler, hidden)
+	}
+	if cfg.OpenAPIDocsPath != "" {
+		assetsURL := cmp.Or(cfg.OpenAPIAssetsURL, openapi.DefaultAssetsURL(cfg.OpenAPIUI))
+		if cfg.OpenAPIAssets != nil {
+			assetsURL = strings.TrimSuffix(cfg.OpenAPIDocsPath, "/") + "/assets"
+			router.handle(&engine.RouterGroup, http.MethodGet, assetsURL+"/*file", gin.WrapH(cfg.OpenAPIAssets), hidden)
+		}
+		docs := router.docsHandler(cfg.OpenAPIUI, cfg.OpenAPIPath, assetsURL)
+		router.handle(&engine.RouterGroup, http.MethodGet, cfg.OpenAPIDocsPath, docs, hidden)
+	}
+
+	// Unmatched routes answer through ErrorHandler (404 and 405 with an Allow header)
+	engine.HandleMethodNotAllowed = true
+	noRoute := cfg.NoRouteHandler
+	if noRoute == nil {
+		noRoute = middleware.NotFoundHandler()
+	}
+	noMethod := cfg.NoMethodHandler
+	if noMethod == nil {
+		noMethod = middleware.MethodNotAllowedHandler(engine.Routes)
+	}
+	engine.NoRoute(noRoute)
+	engine.NoMethod(noMethod)
 
 	// If BasePath is configured, create a base group
 	if cfg.BasePath != "" {
@@ -96,78 +181,107 @@ func (r *GinRouter) Use(middleware ...gin.HandlerFunc) {
 	}
 }
 
-// GET registers a GET route
-func (r *GinRouter) GET(relativePath string, handlers ...gin.HandlerFunc) {
+// Handle registers a route with per-route options (timeout, body limit, logging, tags)
+// The options are readable by every middleware through middleware.GetRouteConfig.
+func (r *GinRouter) Handle(method, relativePath string, handler gin.HandlerFunc, opts ...middleware.RouteOption) {
+	r.handle(r.rootGroup(), method, relativePath, handler, opts)
+}
+
+// handle registers a route together with its configuration and route-level middleware
+func (r *GinRouter) handle(group *gin.RouterGroup, method, relativePath string, handler gin.HandlerFunc, opts []middleware.RouteOption) {
+	rc := middleware.NewRouteConfig(opts...)
+
+	handlers := make([]gin.HandlerFunc, 0, 3)
+	if rc.MaxBodyBytes > 0 {
+		handlers = append(handlers, middleware.MaxBodySize(rc.MaxBodyBytes))
+	}
+	if rc.Timeout > 0 {
+		handlers = append(handlers, middleware.WithTimeout(rc.Timeout))
+	}
+	handlers = append(handlers, handler)
+
+	r.register(group, method, relativePath, handlers, rc)
+}
+
+// rootGroup returns the group routes registered on the router belong to
+func (r *GinRouter) rootGroup() *gin.RouterGroup {
 	if r.baseGroup != nil {
-		r.baseGroup.GET(relativePath, handlers...)
-	} else {
-		r.engine.GET(relativePath, handlers...)
+		return r.baseGroup
 	}
+	return &r.engine.RouterGroup
+}
+
+// Metrics returns the Prometheus metrics, or nil when metrics are disabled
+func (r *GinRouter) Metrics() *middleware.Metrics {
+	return r.metrics
+}
+
+// Health returns the health check registry
+func (r *GinRouter) Health() *middleware.Health {
+	return r.health
+}
+
+// Renderers returns the response formats of the router
+func (r *GinRouter) Renderers() *middleware.RendererRegistry {
+	return r.renderers
+}
+
+// NoRoute replaces the handlers of requests matching no route (default: 404 NotFoundError)
+// Global middleware, including ErrorHandler, runs before them.
+func (r *GinRouter) NoRoute(handlers ...gin.HandlerFunc) {
+	r.engine.NoRoute(handlers...)
+}
+
+// NoMethod replaces the handlers of requests whose path only exists with other methods
+// (default: 405 MethodNotAllowedError with an Allow header)
+func (r *GinRouter) NoMethod(handlers ...gin.HandlerFunc) {
+	r.engine.NoMethod(handlers...)
+}
+
+// GET registers a GET route
+func (r *GinRouter) GET(relativePath string, handlers ...gin.HandlerFunc) {
+	r.register(r.rootGroup(), http.MethodGet, relativePath, handlers, nil)
 }
 
 // POST registers a POST route
 func (r *GinRouter) POST(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.POST(relativePath, handlers...)
-	} else {
-		r.engine.POST(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodPost, relativePath, handlers, nil)
 }
 
 // PUT registers a PUT route
 func (r *GinRouter) PUT(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.PUT(relativePath, handlers...)
-	} else {
-		r.engine.PUT(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodPut, relativePath, handlers, nil)
 }
 
 // DELETE registers a DELETE route
 func (r *GinRouter) DELETE(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.DELETE(relativePath, handlers...)
-	} else {
-		r.engine.DELETE(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodDelete, relativePath, handlers, nil)
 }
 
 // PATCH registers a PATCH route
 func (r *GinRouter) PATCH(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.PATCH(relativePath, handlers...)
-	} else {
-		r.engine.PATCH(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodPatch, relativePath, handlers, nil)
 }
 
 // OPTIONS registers an OPTIONS route
 func (r *GinRouter) OPTIONS(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.OPTIONS(relativePath, handlers...)
-	} else {
-		r.engine.OPTIONS(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodOptions, relativePath, handlers, nil)
 }
 
 // HEAD registers a HEAD route
 func (r *GinRouter) HEAD(relativePath string, handlers ...gin.HandlerFunc) {
-	if r.baseGroup != nil {
-		r.baseGroup.HEAD(relativePath, handlers...)
-	} else {
-		r.engine.HEAD(relativePath, handlers...)
-	}
+	r.register(r.rootGroup(), http.MethodHead, relativePath, handlers, nil)
 }
 
 // Group creates a new route group with the given prefix
 func (r *GinRouter) Group(relativePath string, handlers ...gin.HandlerFunc) *GinRouterGroup {
-	var group *gin.RouterGroup
-	if r.baseGroup != nil {
-		group = r.baseGroup.Group(relativePath, handlers...)
-	} else {
-		group = r.engine.Group(relativePath, handlers...)
-	}
-	return &GinRouterGroup{group: group}
+	group := r.rootGroup().Group(relativePath, handlers...)
+	return &GinRouterGroup{group: group, router: r}
+}
+
+// CORS returns the global CORS middleware, or nil when CORS is disabled
+func (r *GinRouter) CORS() *middleware.CORSMiddleware {
+	return r.cors
 }
 
 // Use adds middleware to the group
@@ -175,43 +289,70 @@ func (g *GinRouterGroup) Use(middleware ...gin.HandlerFunc) {
 	g.group.Use(middleware...)
 }
 
+// Handle registers a route in the group with per-route options
+func (g *GinRouterGroup) Handle(method, relativePath string, handler gin.HandlerFunc, opts ...middleware.RouteOption) {
+	g.router.handle(g.group, method, relativePath, handler, opts)
+}
+
 // GET registers a GET route in the group
 func (g *GinRouterGroup) GET(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.GET(relativePath, handlers...)
+	g.router.register(g.group, http.MethodGet, relativePath, handlers, nil)
 }
 
 // POST registers a POST route in the group
 func (g *GinRouterGroup) POST(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.POST(relativePath, handlers...)
+	g.router.register(g.group, http.MethodPost, relativePath, handlers, nil)
 }
 
 // PUT registers a PUT route in the group
 func (g *GinRouterGroup) PUT(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.PUT(relativePath, handlers...)
+	g.router.register(g.group, http.MethodPut, relativePath, handlers, nil)
 }
 
 // DELETE registers a DELETE route in the group
 func (g *GinRouterGroup) DELETE(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.DELETE(relativePath, handlers...)
+	g.router.register(g.group, http.MethodDelete, relativePath, handlers, nil)
 }
 
 // PATCH registers a PATCH route in the group
 func (g *GinRouterGroup) PATCH(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.PATCH(relativePath, handlers...)
+	g.router.register(g.group, http.MethodPatch, relativePath, handlers, nil)
 }
 
 // OPTIONS registers an OPTIONS route in the group
 func (g *GinRouterGroup) OPTIONS(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.OPTIONS(relativePath, handlers...)
+	g.router.register(g.group, http.MethodOptions, relativePath, handlers, nil)
 }
 
 // HEAD registers a HEAD route in the group
 func (g *GinRouterGroup) HEAD(relativePath string, handlers ...gin.HandlerFunc) {
-	g.group.HEAD(relativePath, handlers...)
+	g.router.register(g.group, http.MethodHead, relativePath, handlers, nil)
 }
 
 // Group creates a nested route group
 func (g *GinRouterGroup) Group(relativePath string, handlers ...gin.HandlerFunc) *GinRouterGroup {
 	nestedGroup := g.group.Group(relativePath, handlers...)
-	return &GinRouterGroup{group: nestedGroup}
+	return &GinRouterGroup{group: nestedGroup, router: g.router}
+}
+
+// CORS overrides the CORS policy for every route under this group
+// When global CORS is enabled the override is resolved by the global middleware,
+// so preflight requests are answered even without OPTIONS routes. Otherwise the
+// policy is attached as group middleware and only applies to registered routes.
+// Malformed origin patterns are reported by ValidateRoutes and the policy is not applied.
+func (g *GinRouterGroup) CORS(cfg middleware.CORSConfig) {
+	if cfg.Origins == nil {
+		if err := middleware.ValidateOrigins(cfg.AllowedOrigins); err != nil {
+			g.router.mu.Lock()
+			g.router.conflicts = append(g.router.conflicts, fmt.Sprintf("CORS %s: %v", g.group.BasePath(), err))
+			g.router.mu.Unlock()
+			return
+		}
+	}
+
+	if g.router.cors != nil {
+		g.router.cors.Override(g.group.BasePath(), cfg)
+		return
+	}
+	g.group.Use(middleware.CORS(cfg))
 }
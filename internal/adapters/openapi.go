@@ -0,0 +1,48 @@
+package adapters
+
+import (
+	"net/http"
+
+	"github.com/edaniel30/http-platform-go/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI generates the OpenAPI document of the registered routes
+// Routes registered with Route.Hidden() (including the documentation endpoints) are excluded.
+func (r *GinRouter) OpenAPI() *openapi.Document {
+	routes := r.Routes()
+	input := make([]openapi.Route, 0, len(routes))
+	for _, route := range routes {
+		op := openapi.Route{Method: route.Method, Path: route.Path}
+		if rc := route.Config; rc != nil {
+			if rc.Hidden {
+				continue
+			}
+			op.Summary = rc.Summary
+			op.Description = rc.Description
+			op.Tags = rc.Tags
+			op.Request = rc.Request
+			for _, resp := range rc.Responses {
+				op.Responses = append(op.Responses, openapi.RouteResponse{Status: resp.Status, Body: resp.Body})
+			}
+		}
+		input = append(input, op)
+	}
+
+	return openapi.Generate(r.apiInfo, input, r.apiOpts...)
+}
+
+// openAPIHandler serves the generated OpenAPI document
+// The document is generated per request so routes registered after startup are included.
+func (r *GinRouter) openAPIHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, r.OpenAPI())
+}
+
+// docsHandler returns a handler serving the documentation UI for the given spec URL
+// The page loads the UI scripts and styles from assetsURL.
+func (r *GinRouter) docsHandler(ui, specURL, assetsURL string) gin.HandlerFunc {
+	page := openapi.DocsPage(ui, r.apiInfo.Title, specURL, assetsURL)
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	}
+}
@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edaniel30/http-platform-go/middleware"
+	config "github.com/edaniel30/http-platform-go/models"
+	"github.com/edaniel30/http-platform-go/openapi"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(context.Context, string, middleware.Fields)  {}
+func (nopLogger) Error(context.Context, string, middleware.Fields) {}
+func (nopLogger) Warn(context.Context, string, middleware.Fields)  {}
+func (nopLogger) Debug(context.Context, string, middleware.Fields) {}
+func (nopLogger) Close() error                                     { return nil }
+
+func TestDocsAssets(t *testing.T) {
+	assets := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("asset " + r.URL.Path))
+	})
+	tests := []struct {
+		name       string
+		opt        config.Option
+		wantScript string
+		assetCode  int
+	}{
+		{"cdn", func(*config.Config) {}, openapi.SwaggerAssetsURL + "/swagger-ui-bundle.js", http.StatusNotFound},
+		{"mirror", config.WithOpenAPIAssetsURL("https://static.internal/swagger"), "https://static.internal/swagger/swagger-ui-bundle.js", http.StatusNotFound},
+		{"handler", config.WithOpenAPIAssets(assets), "/docs/assets/swagger-ui-bundle.js", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Mode = "test"
+			cfg.Logger = nopLogger{}
+			config.WithOpenAPI("/openapi.json", "/docs")(&cfg)
+			tt.opt(&cfg)
+			handler := NewGinRouter(cfg).Handler()
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+			if !strings.Contains(rec.Body.String(), `src="`+tt.wantScript+`"`) {
+				t.Errorf("docs page does not load %s:\n%s", tt.wantScript, rec.Body.String())
+			}
+
+			rec = httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/assets/swagger-ui-bundle.js", nil))
+			if rec.Code != tt.assetCode {
+				t.Errorf("asset status = %d, want %d", rec.Code, tt.assetCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package adapters
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// RouteInfo describes a registered route
+type RouteInfo struct {
+	// Method is the HTTP method (e.g., "GET")
+	Method string
+
+	// Path is the full path template including BasePath and group prefixes (e.g., "/api/v1/users/:id")
+	Path string
+
+	// Handler is the name of the final handler function
+	Handler string
+
+	// Middleware lists the names of the handlers that run before Handler, in order
+	// It includes global, group and route-level middleware attached when the route was registered.
+	Middleware []string
+
+	// Config holds the options the route was registered with, or nil for plain routes
+	Config *middleware.RouteConfig
+}
+
+// Routes returns every registered route in registration order
+func (r *GinRouter) Routes() []RouteInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.table)
+}
+
+// ValidateRoutes returns an error describing every route that could not be registered
+// (duplicates, conflicting wildcards or invalid paths) and every group CORS policy with malformed
+// origin patterns. Returns nil if all routes are valid.
+func (r *GinRouter) ValidateRoutes() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.conflicts) == 0 {
+		return nil
+	}
+	return errors.ErrRouteConflicts(r.conflicts)
+}
+
+// register adds a route to gin and to the route table
+// Routes gin would reject are detected before they reach gin's tree and recorded instead, so
+// ValidateRoutes reports them before the server starts; the rejected route is skipped.
+func (r *GinRouter) register(group *gin.RouterGroup, method, relativePath string, handlers []gin.HandlerFunc, rc *middleware.RouteConfig) {
+	fullPath := joinPaths(group.BasePath(), relativePath)
+	chain := append(slices.Clone(group.Handlers), handlers...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := checkRoute(method, fullPath, chain); err != nil {
+		r.conflicts = append(r.conflicts, fmt.Sprintf("%s %s: %v", method, fullPath, err))
+		return
+	}
+
+	// Duplicates (including routes differing only by param names) and wildcard conflicts are
+	// checked against the routes of the same method, which share a tree in gin
+	key := routeShape(fullPath)
+	for _, existing := range r.table {
+		if existing.Method != method {
+			continue
+		}
+		if routeShape(existing.Path) == key || wildcardConflict(existing.Path, fullPath) {
+			r.conflicts = append(r.conflicts, fmt.Sprintf("%s %s conflicts with %s %s (handler %s)",
+				method, fullPath, existing.Method, existing.Path, existing.Handler))
+			return
+		}
+	}
+
+	group.Handle(method, relativePath, handlers...)
+
+	info := RouteInfo{
+		Method:     method,
+		Path:       fullPath,
+		Middleware: make([]string, 0, len(chain)),
+		Config:     rc,
+	}
+	if len(chain) > 0 {
+		info.Handler = nameOfFunction(chain[len(chain)-1])
+		for _, h := range chain[:len(chain)-1] {
+			info.Middleware = append(info.Middleware, nameOfFunction(h))
+		}
+	}
+	r.table = append(r.table, info)
+
+	if rc != nil {
+		r.routes.Register(method, fullPath, rc)
+	}
+}
+
+// httpMethod matches the methods gin accepts
+var httpMethod = regexp.MustCompile("^[A-Z]+$")
+
+// checkRoute reports the method, path syntax and handler errors gin panics on
+func checkRoute(method, fullPath string, chain []gin.HandlerFunc) error {
+	if !httpMethod.MatchString(method) {
+		return fmt.Errorf("http method %s is not valid", method)
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("there must be at least one handler")
+	}
+
+	for i := 0; i < len(fullPath); i++ {
+		c := fullPath[i]
+		if c != ':' && c != '*' {
+			continue
+		}
+		end := wildcardEnd(fullPath, i)
+		wildcard := fullPath[i:end]
+		switch {
+		case strings.ContainsAny(wildcard[1:], ":*"):
+			return fmt.Errorf("only one wildcard per path segment is allowed, has: '%s'", wildcard)
+		case len(wildcard) == 1:
+			return fmt.Errorf("wildcards must be named with a non-empty name")
+		case c == '*' && end != len(fullPath):
+			return fmt.Errorf("catch-all routes are only allowed at the end of the path")
+		case c == '*' && (i == 0 || fullPath[i-1] != '/'):
+			return fmt.Errorf("no / before catch-all")
+		}
+		i = end - 1
+	}
+	return nil
+}
+
+// wildcardConflict reports whether gin rejects newPath next to existing in the tree of a method:
+// where the paths diverge, a catch-all conflicts with anything and a param with another param,
+// while a param and a static segment can coexist (e.g., "/users/:id" and "/users/new").
+// A catch-all also conflicts with a route ending where it starts (e.g., "/files/" and "/files/*path").
+func wildcardConflict(existing, newPath string) bool {
+	a, b := routeTokens(existing), routeTokens(newPath)
+	k := 0
+	for k < len(a) && k < len(b) && a[k] == b[k] {
+		k++
+	}
+
+	switch {
+	case k == len(a) && k == len(b):
+		return true
+	case k == len(a):
+		return b[k][0] == '*'
+	case k == len(b):
+		return a[k][0] == '*'
+	}
+	return a[k][0] == '*' || b[k][0] == '*' || (a[k][0] == ':' && b[k][0] == ':')
+}
+
+// routeTokens splits a path template into single static bytes and whole wildcards (":id", "*path")
+func routeTokens(fullPath string) []string {
+	tokens := make([]string, 0, len(fullPath))
+	for i := 0; i < len(fullPath); {
+		end := i + 1
+		if c := fullPath[i]; c == ':' || c == '*' {
+			end = wildcardEnd(fullPath, i)
+		}
+		tokens = append(tokens, fullPath[i:end])
+		i = end
+	}
+	return tokens
+}
+
+// wildcardEnd returns the end of the wildcard starting at i, the next '/' or the end of the path
+func wildcardEnd(fullPath string, i int) int {
+	if end := strings.IndexByte(fullPath[i:], '/'); end >= 0 {
+		return i + end
+	}
+	return len(fullPath)
+}
+
+// routeShape normalizes a path template so routes differing only by parameter names compare equal
+// e.g. "/users/:id" and "/users/:uid" both become "/users/:"
+func routeShape(fullPath string) string {
+	segments := strings.Split(fullPath, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = ":"
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// routesHandler serves the route table as JSON (debug endpoint)
+func (r *GinRouter) routesHandler(c *gin.Context) {
+	routes := r.Routes()
+	entries := make([]gin.H, 0, len(routes))
+	for _, route := range routes {
+		entry := gin.H{
+			"method":     route.Method,
+			"path":       route.Path,
+			"handler":    route.Handler,
+			"middleware": route.Middleware,
+		}
+		if rc := route.Config; rc != nil {
+			options := gin.H{}
+			if rc.Timeout > 0 {
+				options["timeout"] = rc.Timeout.String()
+			}
+			if rc.MaxBodyBytes > 0 {
+				options["max_body_bytes"] = rc.MaxBodyBytes
+			}
+			if rc.NoLog {
+				options["no_log"] = true
+			}
+			if len(rc.Tags) > 0 {
+				options["tags"] = rc.Tags
+			}
+			entry["options"] = options
+		}
+		entries = append(entries, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": len(entries), "routes": entries})
+}
+
+// nameOfFunction returns the fully qualified name of a handler function
+func nameOfFunction(f any) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+// joinPaths joins a base path and a relative path the way gin does,
+// preserving a trailing slash on the relative path
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+
+	finalPath := path.Join(absolutePath, relativePath)
+	if relativePath[len(relativePath)-1] == '/' && finalPath[len(finalPath)-1] != '/' {
+		return finalPath + "/"
+	}
+	return finalPath
+}
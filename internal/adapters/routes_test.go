@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter() *GinRouter {
+	gin.SetMode(gin.TestMode)
+	return &GinRouter{engine: gin.New(), routes: middleware.NewRouteRegistry()}
+}
+
+func ok(c *gin.Context) { c.String(http.StatusOK, c.FullPath()) }
+
+func TestRegisterRejectsRoutesBeforeGin(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		method   string
+		path     string
+		want     string
+	}{
+		{"duplicate param names", []string{"/users/:id"}, http.MethodGet, "/users/:uid", "conflicts with GET /users/:id"},
+		{"param names", []string{"/users/:id"}, http.MethodGet, "/users/:name/posts", "conflicts with GET /users/:id"},
+		{"catch-all after static", []string{"/files/readme"}, http.MethodGet, "/files/*path", "conflicts with GET /files/readme"},
+		{"static after catch-all", []string{"/files/*path"}, http.MethodGet, "/files/", "conflicts with GET /files/*path"},
+		{"catch-all after param", []string{"/files/:id/meta"}, http.MethodGet, "/files/*path", "conflicts with GET /files/:id/meta"},
+		{"unnamed wildcard", nil, http.MethodGet, "/users/:", "wildcards must be named"},
+		{"two wildcards", nil, http.MethodGet, "/users/:id:name", "only one wildcard per path segment"},
+		{"catch-all not last", nil, http.MethodGet, "/files/*path/meta", "only allowed at the end of the path"},
+		{"invalid method", nil, "get", "/users", "http method get is not valid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter()
+			for _, path := range tt.existing {
+				r.register(&r.engine.RouterGroup, http.MethodGet, path, []gin.HandlerFunc{ok}, nil)
+			}
+
+			r.register(&r.engine.RouterGroup, tt.method, tt.path, []gin.HandlerFunc{ok}, nil)
+
+			err := r.ValidateRoutes()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ValidateRoutes() = %v, want %q", err, tt.want)
+			}
+			if got := len(r.Routes()); got != len(tt.existing) {
+				t.Errorf("%d routes registered, want %d", got, len(tt.existing))
+			}
+			// The rejected route left gin's tree untouched: existing routes still match
+			for _, path := range tt.existing {
+				rec := httptest.NewRecorder()
+				r.engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, strings.NewReplacer(":id", "1", "*path", "a").Replace(path), nil))
+				if rec.Code != http.StatusOK || rec.Body.String() != path {
+					t.Errorf("GET %s = %d %q, want 200 %q", path, rec.Code, rec.Body.String(), path)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterAcceptsCoexistingRoutes(t *testing.T) {
+	r := newTestRouter()
+	for _, path := range []string{"/users/:id", "/users/new", "/users/:id/posts", "/files/:id", "/files/:id/*rest", "/v1/:id", "/v2/:name"} {
+		r.register(&r.engine.RouterGroup, http.MethodGet, path, []gin.HandlerFunc{ok}, nil)
+	}
+	r.register(&r.engine.RouterGroup, http.MethodPost, "/users/:uid", []gin.HandlerFunc{ok}, nil)
+
+	if err := r.ValidateRoutes(); err != nil {
+		t.Fatalf("ValidateRoutes() = %v", err)
+	}
+	if got := len(r.Routes()); got != 8 {
+		t.Errorf("%d routes registered, want 8", got)
+	}
+}
+
+func TestGroupCORSReportsMalformedOrigins(t *testing.T) {
+	for _, global := range []bool{false, true} {
+		r := newTestRouter()
+		if global {
+			r.cors = middleware.NewCORS(middleware.CORSConfig{AllowedOrigins: []string{"https://myapp.com"}})
+		}
+
+		r.Group("/admin").CORS(middleware.CORSConfig{AllowedOrigins: []string{"regex:^https://(admin"}})
+
+		err := r.ValidateRoutes()
+		if err == nil || !strings.Contains(err.Error(), "CORS /admin") {
+			t.Errorf("global CORS %v: ValidateRoutes() = %v, want the malformed origin reported", global, err)
+		}
+	}
+}
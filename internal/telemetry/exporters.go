@@ -0,0 +1,460 @@
+package telemetry
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// Exporters
+const (
+	ExporterOTLP   = "otlp"   // OTLP to a collector or agent
+	ExporterStdout = "stdout" // JSON to standard output, for local debugging
+	ExporterFile   = "file"   // JSON lines appended to a file, for local debugging
+)
+
+// OTLP protocols
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+// ErrProbeFailed is returned by Init when the probe export request failed
+// The configuration is valid; the collector is unreachable or rejected the export, which
+// may be temporary.
+var ErrProbeFailed = errors.New("telemetry probe export failed")
+
+// defaultExportTimeout bounds the probe export when ExporterConfig.Timeout is not set
+const defaultExportTimeout = 10 * time.Second
+
+// Compression values
+const (
+	CompressionGzip = "gzip"
+	CompressionNone = "none"
+)
+
+// ExporterConfig configures the exporters of traces, metrics and logs
+// Settings left empty fall back to the standard OTEL_EXPORTER_OTLP_* environment variables,
+// and the endpoint environment variables take precedence over Endpoint. When one of them is set,
+// Insecure and URLPath are ignored as well: the URL scheme selects TLS (http:// is plaintext)
+// and the URL path is used as is.
+type ExporterConfig struct {
+	Exporter    string            // "otlp" (default), "stdout" or "file"
+	Protocol    string            // "http/protobuf" or "grpc" (default: OTEL_EXPORTER_OTLP_PROTOCOL, then "http/protobuf")
+	Endpoint    string            // host:port of the collector
+	Insecure    bool              // Plaintext connection, for a local agent
+	CAFile      string            // CA certificate verifying the collector
+	CertFile    string            // Client certificate (mTLS)
+	KeyFile     string            // Client key (mTLS)
+	Headers     map[string]string // Headers sent with every export (e.g., API keys), rejected over plaintext to a remote endpoint
+	Compression string            // "gzip" or "none"
+	URLPath     string            // Prefix of the HTTP signal paths (e.g., "/otlp" gives /otlp/v1/traces)
+	Timeout     time.Duration     // Timeout of each export (default: 10s)
+	Retry       *RetryConfig      // Retry of failed exports (default: enabled, 5s initial, 30s max, 1m elapsed)
+	FilePath    string            // Output file of the "file" exporter
+}
+
+// RetryConfig configures the retry of failed exports
+// Its fields match the retry configuration of the OTLP exporters.
+type RetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// Validate checks the exporter settings
+func (c ExporterConfig) Validate() error {
+	switch c.Exporter {
+	case "", ExporterOTLP, ExporterStdout:
+	case ExporterFile:
+		if c.FilePath == "" {
+			return fmt.Errorf("the file exporter requires a file path")
+		}
+	default:
+		return fmt.Errorf("unknown exporter '%s' (supported: otlp, stdout, file)", c.Exporter)
+	}
+
+	switch c.Protocol {
+	case "", ProtocolHTTP, ProtocolGRPC:
+	default:
+		return fmt.Errorf("unknown OTLP protocol '%s' (supported: http/protobuf, grpc)", c.Protocol)
+	}
+
+	switch c.Compression {
+	case "", CompressionGzip, CompressionNone:
+	default:
+		return fmt.Errorf("unknown OTLP compression '%s' (supported: gzip, none)", c.Compression)
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("OTLP client certificate and key must be set together")
+	}
+	if c.plaintextHeaders() {
+		return fmt.Errorf("OTLP headers would be sent in plaintext to %s: enable TLS or use a loopback endpoint", c.Endpoint)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("OTLP timeout cannot be negative")
+	}
+	if c.Retry != nil && (c.Retry.InitialInterval < 0 || c.Retry.MaxInterval < 0 || c.Retry.MaxElapsedTime < 0) {
+		return fmt.Errorf("OTLP retry intervals cannot be negative")
+	}
+	return nil
+}
+
+// Signals, as named in the OTEL_EXPORTER_OTLP_<SIGNAL>_* environment variables
+const (
+	signalTraces  = "TRACES"
+	signalMetrics = "METRICS"
+	signalLogs    = "LOGS"
+)
+
+// protocol returns the OTLP protocol of a signal: the configured one, or the one of the environment
+func (c ExporterConfig) protocol(signal string) (string, error) {
+	protocol := c.Protocol
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL")
+	}
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+
+	switch protocol {
+	case "":
+		return ProtocolHTTP, nil
+	case ProtocolHTTP, ProtocolGRPC:
+		return protocol, nil
+	default:
+		return "", fmt.Errorf("unsupported OTLP protocol '%s' (supported: http/protobuf, grpc)", protocol)
+	}
+}
+
+// endpointFromEnv reports whether the environment sets the endpoint of a signal
+// The exporters then read the endpoint (and its scheme, for TLS) from the environment.
+func endpointFromEnv(signal string) bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT") != ""
+}
+
+// plaintextHeaders reports whether the headers, typically API keys, would leave the host unencrypted:
+// the configured endpoint is remote and the connection is insecure
+// Endpoints set through the environment are left out, their scheme selects TLS.
+func (c ExporterConfig) plaintextHeaders() bool {
+	if len(c.Headers) == 0 || !c.Insecure || c.CAFile != "" || c.CertFile != "" {
+		return false
+	}
+	if c.Exporter != "" && c.Exporter != ExporterOTLP {
+		return false
+	}
+	if endpointFromEnv(signalTraces) && endpointFromEnv(signalMetrics) && endpointFromEnv(signalLogs) {
+		return false
+	}
+	return !isLoopback(c.Endpoint)
+}
+
+// isLoopback reports whether a host:port endpoint is on the local host (an empty endpoint is
+// the localhost default of the exporters)
+func isLoopback(endpoint string) bool {
+	if endpoint == "" {
+		return true
+	}
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// tlsConfig loads the configured certificates, nil when none is set
+func (c ExporterConfig) tlsConfig() (*tls.Config, error) {
+	if c.CAFile == "" && c.CertFile == "" {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP CA file: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in OTLP CA file %s", c.CAFile)
+		}
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OTLP client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// otlpOptions builds the options shared by the OTLP exporters of every signal and protocol
+// O is the option type of an exporter package; urlPath is nil for gRPC exporters.
+type otlpOptions[O any] struct {
+	endpoint    func(string) O
+	insecure    func() O
+	urlPath     func(string) O
+	tls         func(*tls.Config) O
+	headers     func(map[string]string) O
+	compression func(gzip bool) O
+	timeout     func(time.Duration) O
+	retry       func(RetryConfig) O
+}
+
+// build returns the options of the settings set in cfg, leaving the others to the environment
+func (o otlpOptions[O]) build(cfg ExporterConfig, signal, signalPath string, tlsCfg *tls.Config) []O {
+	var opts []O
+	if !endpointFromEnv(signal) {
+		if cfg.Endpoint != "" {
+			opts = append(opts, o.endpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure && tlsCfg == nil {
+			opts = append(opts, o.insecure())
+		}
+		if cfg.URLPath != "" && o.urlPath != nil {
+			opts = append(opts, o.urlPath(path.Join("/", cfg.URLPath, signalPath)))
+		}
+	}
+	if tlsCfg != nil {
+		opts = append(opts, o.tls(tlsCfg))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, o.headers(cfg.Headers))
+	}
+	// gRPC exporters only accept gzip, so "none" keeps their default (or environment) setting
+	if cfg.Compression == CompressionGzip || (cfg.Compression == CompressionNone && o.urlPath != nil) {
+		opts = append(opts, o.compression(cfg.Compression == CompressionGzip))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, o.timeout(cfg.Timeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, o.retry(*cfg.Retry))
+	}
+	return opts
+}
+
+// newTraceExporter creates the span exporter; w receives the output of the stdout and file exporters
+func newTraceExporter(ctx context.Context, cfg ExporterConfig, w io.Writer) (sdktrace.SpanExporter, error) {
+	if cfg.Exporter == ExporterStdout || cfg.Exporter == ExporterFile {
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	}
+
+	protocol, err := cfg.protocol(signalTraces)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var client otlptrace.Client
+	if protocol == ProtocolGRPC {
+		client = otlptracegrpc.NewClient(otlpOptions[otlptracegrpc.Option]{
+			endpoint: otlptracegrpc.WithEndpoint,
+			insecure: otlptracegrpc.WithInsecure,
+			tls: func(c *tls.Config) otlptracegrpc.Option {
+				return otlptracegrpc.WithTLSCredentials(credentials.NewTLS(c))
+			},
+			headers: otlptracegrpc.WithHeaders,
+			compression: func(bool) otlptracegrpc.Option {
+				return otlptracegrpc.WithCompressor(CompressionGzip)
+			},
+			timeout: otlptracegrpc.WithTimeout,
+			retry: func(rc RetryConfig) otlptracegrpc.Option {
+				return otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(rc))
+			},
+		}.build(cfg, signalTraces, "", tlsCfg)...)
+	} else {
+		client = otlptracehttp.NewClient(otlpOptions[otlptracehttp.Option]{
+			endpoint: otlptracehttp.WithEndpoint,
+			insecure: otlptracehttp.WithInsecure,
+			urlPath:  otlptracehttp.WithURLPath,
+			tls:      otlptracehttp.WithTLSClientConfig,
+			headers:  otlptracehttp.WithHeaders,
+			compression: func(gzip bool) otlptracehttp.Option {
+				if gzip {
+					return otlptracehttp.WithCompression(otlptracehttp.GzipCompression)
+				}
+				return otlptracehttp.WithCompression(otlptracehttp.NoCompression)
+			},
+			timeout: otlptracehttp.WithTimeout,
+			retry: func(rc RetryConfig) otlptracehttp.Option {
+				return otlptracehttp.WithRetry(otlptracehttp.RetryConfig(rc))
+			},
+		}.build(cfg, signalTraces, "/v1/traces", tlsCfg)...)
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return &otlpTraceExporter{Exporter: exporter, client: client}, nil
+}
+
+// otlpTraceExporter keeps the client of an OTLP span exporter, to probe the collector
+type otlpTraceExporter struct {
+	*otlptrace.Exporter
+	client otlptrace.Client
+}
+
+// newMetricExporter creates the metric exporter; w receives the output of the stdout and file exporters
+func newMetricExporter(ctx context.Context, cfg ExporterConfig, w io.Writer) (sdkmetric.Exporter, error) {
+	if cfg.Exporter == ExporterStdout || cfg.Exporter == ExporterFile {
+		return stdoutmetric.New(stdoutmetric.WithWriter(w))
+	}
+
+	protocol, err := cfg.protocol(signalMetrics)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol == ProtocolGRPC {
+		return otlpmetricgrpc.New(ctx, otlpOptions[otlpmetricgrpc.Option]{
+			endpoint: otlpmetricgrpc.WithEndpoint,
+			insecure: otlpmetricgrpc.WithInsecure,
+			tls: func(c *tls.Config) otlpmetricgrpc.Option {
+				return otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(c))
+			},
+			headers: otlpmetricgrpc.WithHeaders,
+			compression: func(bool) otlpmetricgrpc.Option {
+				return otlpmetricgrpc.WithCompressor(CompressionGzip)
+			},
+			timeout: otlpmetricgrpc.WithTimeout,
+			retry: func(rc RetryConfig) otlpmetricgrpc.Option {
+				return otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(rc))
+			},
+		}.build(cfg, signalMetrics, "", tlsCfg)...)
+	}
+
+	return otlpmetrichttp.New(ctx, otlpOptions[otlpmetrichttp.Option]{
+		endpoint: otlpmetrichttp.WithEndpoint,
+		insecure: otlpmetrichttp.WithInsecure,
+		urlPath:  otlpmetrichttp.WithURLPath,
+		tls:      otlpmetrichttp.WithTLSClientConfig,
+		headers:  otlpmetrichttp.WithHeaders,
+		compression: func(gzip bool) otlpmetrichttp.Option {
+			if gzip {
+				return otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression)
+			}
+			return otlpmetrichttp.WithCompression(otlpmetrichttp.NoCompression)
+		},
+		timeout: otlpmetrichttp.WithTimeout,
+		retry: func(rc RetryConfig) otlpmetrichttp.Option {
+			return otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(rc))
+		},
+	}.build(cfg, signalMetrics, "/v1/metrics", tlsCfg)...)
+}
+
+// newLogExporter creates the log exporter; w receives the output of the stdout and file exporters
+func newLogExporter(ctx context.Context, cfg ExporterConfig, w io.Writer) (sdklog.Exporter, error) {
+	if cfg.Exporter == ExporterStdout || cfg.Exporter == ExporterFile {
+		return stdoutlog.New(stdoutlog.WithWriter(w))
+	}
+
+	protocol, err := cfg.protocol(signalLogs)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol == ProtocolGRPC {
+		return otlploggrpc.New(ctx, otlpOptions[otlploggrpc.Option]{
+			endpoint: otlploggrpc.WithEndpoint,
+			insecure: otlploggrpc.WithInsecure,
+			tls: func(c *tls.Config) otlploggrpc.Option {
+				return otlploggrpc.WithTLSCredentials(credentials.NewTLS(c))
+			},
+			headers: otlploggrpc.WithHeaders,
+			compression: func(bool) otlploggrpc.Option {
+				return otlploggrpc.WithCompressor(CompressionGzip)
+			},
+			timeout: otlploggrpc.WithTimeout,
+			retry: func(rc RetryConfig) otlploggrpc.Option {
+				return otlploggrpc.WithRetry(otlploggrpc.RetryConfig(rc))
+			},
+		}.build(cfg, signalLogs, "", tlsCfg)...)
+	}
+
+	return otlploghttp.New(ctx, otlpOptions[otlploghttp.Option]{
+		endpoint: otlploghttp.WithEndpoint,
+		insecure: otlploghttp.WithInsecure,
+		urlPath:  otlploghttp.WithURLPath,
+		tls:      otlploghttp.WithTLSClientConfig,
+		headers:  otlploghttp.WithHeaders,
+		compression: func(gzip bool) otlploghttp.Option {
+			if gzip {
+				return otlploghttp.WithCompression(otlploghttp.GzipCompression)
+			}
+			return otlploghttp.WithCompression(otlploghttp.NoCompression)
+		},
+		timeout: otlploghttp.WithTimeout,
+		retry: func(rc RetryConfig) otlploghttp.Option {
+			return otlploghttp.WithRetry(otlploghttp.RetryConfig(rc))
+		},
+	}.build(cfg, signalLogs, "/v1/logs", tlsCfg)...)
+}
+
+// openExportFile opens the output file of the "file" exporter, appending to existing content
+func openExportFile(filePath string) (*os.File, error) {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open telemetry export file: %w", err)
+	}
+	return f, nil
+}
+
+// probeExporter sends an empty trace export request and returns the export error
+// The request carries no span, so probing leaves nothing in the tracing backend.
+func probeExporter(ctx context.Context, exporter sdktrace.SpanExporter, timeout time.Duration) error {
+	otlp, ok := exporter.(*otlpTraceExporter)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(timeout, defaultExportTimeout))
+	defer cancel()
+	if err := otlp.client.UploadTraces(ctx, nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrProbeFailed, err)
+	}
+	return nil
+}
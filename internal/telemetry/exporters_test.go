@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExporterConfigRejectsPlaintextHeaders(t *testing.T) {
+	apiKey := map[string]string{"DD-API-KEY": "secret"}
+	tests := []struct {
+		name    string
+		cfg     ExporterConfig
+		env     string
+		wantErr bool
+	}{
+		{"remote plaintext", ExporterConfig{Endpoint: "collector.example.com:4318", Insecure: true, Headers: apiKey}, "", true},
+		{"remote ip plaintext", ExporterConfig{Endpoint: "10.0.0.7:4317", Insecure: true, Headers: apiKey}, "", true},
+		{"remote tls", ExporterConfig{Endpoint: "collector.example.com:4318", Headers: apiKey}, "", false},
+		{"remote ca file", ExporterConfig{Endpoint: "collector.example.com:4318", Insecure: true, CAFile: "ca.pem", Headers: apiKey}, "", false},
+		{"localhost", ExporterConfig{Endpoint: "localhost:4318", Insecure: true, Headers: apiKey}, "", false},
+		{"loopback ipv6", ExporterConfig{Endpoint: "[::1]:4318", Insecure: true, Headers: apiKey}, "", false},
+		{"default endpoint", ExporterConfig{Insecure: true, Headers: apiKey}, "", false},
+		{"no headers", ExporterConfig{Endpoint: "collector.example.com:4318", Insecure: true}, "", false},
+		{"stdout", ExporterConfig{Exporter: ExporterStdout, Endpoint: "collector.example.com:4318", Insecure: true, Headers: apiKey}, "", false},
+		{"environment endpoint", ExporterConfig{Endpoint: "collector.example.com:4318", Insecure: true, Headers: apiKey}, "https://collector.example.com:4318", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.env)
+			for _, signal := range []string{signalTraces, signalMetrics, signalLogs} {
+				t.Setenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT", "")
+			}
+
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "plaintext") {
+				t.Errorf("Validate() = %v, want a plaintext headers error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Resource detectors
+const (
+	DetectorHost       = "host"       // host.name, os.type, os.description
+	DetectorProcess    = "process"    // process.pid, process.executable.name, process.runtime.*
+	DetectorContainer  = "container"  // container.id, read from the cgroup (v1) or the mounts (v2) of the process
+	DetectorKubernetes = "kubernetes" // k8s.pod.name, k8s.namespace.name, k8s.node.name, k8s.pod.uid
+)
+
+// detectors lists the supported detectors
+var detectors = []string{DetectorHost, DetectorProcess, DetectorContainer, DetectorKubernetes}
+
+// serviceAttributes are set from the service name, version and environment, never from Attributes
+var serviceAttributes = []attribute.Key{semconv.ServiceNameKey, semconv.ServiceVersionKey, semconv.DeploymentEnvironmentKey}
+
+// mountinfoPath lists the mounts of the process, read by the container detector under cgroup v2
+var mountinfoPath = "/proc/self/mountinfo"
+
+// mountinfoContainerIDRe matches the container directory of the hostname, hosts and resolv.conf
+// bind mounts set up by Docker ("/var/lib/docker/containers/<id>/") and Podman
+// ("/containers/storage/overlay-containers/<id>/")
+var mountinfoContainerIDRe = regexp.MustCompile(`[/-]containers/([0-9a-f]{64})/`)
+
+// Downward API environment variables read by the Kubernetes detector, first set wins
+var (
+	kubernetesPodNameEnv   = []string{"K8S_POD_NAME", "POD_NAME"}
+	kubernetesNamespaceEnv = []string{"K8S_NAMESPACE_NAME", "K8S_POD_NAMESPACE", "POD_NAMESPACE"}
+	kubernetesNodeNameEnv  = []string{"K8S_NODE_NAME", "NODE_NAME"}
+	kubernetesPodUIDEnv    = []string{"K8S_POD_UID", "POD_UID"}
+)
+
+// ResourceConfig configures the resource describing the service on spans, metrics and logs
+// Attributes take precedence over detected values. They cannot set the service name, version
+// or environment, which come from Config.
+type ResourceConfig struct {
+	Detectors  []string          // Detectors to run (e.g., DetectorHost, DetectorKubernetes)
+	Attributes map[string]string // Additional attributes (e.g., {"team": "payments"})
+}
+
+// Validate checks the detector names and attribute keys
+func (c ResourceConfig) Validate() error {
+	for _, detector := range c.Detectors {
+		if !slices.Contains(detectors, detector) {
+			return fmt.Errorf("invalid resource detector: '%s' (must be %s, %s, %s or %s)",
+				detector, DetectorHost, DetectorProcess, DetectorContainer, DetectorKubernetes)
+		}
+	}
+	for key := range c.Attributes {
+		if key == "" {
+			return errors.New("resource attribute keys cannot be empty")
+		}
+		if slices.Contains(serviceAttributes, attribute.Key(key)) {
+			return fmt.Errorf("resource attribute '%s' is set from the service name, version and environment", key)
+		}
+	}
+	return nil
+}
+
+// newResource creates the resource of the service
+// Detectors that find nothing (e.g., no container ID outside a container) add no attribute.
+func newResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	if err := cfg.Resource.Validate(); err != nil {
+		return nil, err
+	}
+
+	var opts []resource.Option
+	for _, detector := range cfg.Resource.Detectors {
+		switch detector {
+		case DetectorHost:
+			opts = append(opts, resource.WithHost(), resource.WithOS())
+		case DetectorProcess:
+			// Command line arguments and owner are left out: arguments may hold secrets
+			opts = append(opts,
+				resource.WithProcessPID(),
+				resource.WithProcessExecutableName(),
+				resource.WithProcessRuntimeName(),
+				resource.WithProcessRuntimeVersion(),
+				resource.WithProcessRuntimeDescription(),
+			)
+		case DetectorContainer:
+			opts = append(opts, resource.WithDetectors(containerDetector{}))
+		case DetectorKubernetes:
+			opts = append(opts, resource.WithDetectors(kubernetesDetector{}))
+		}
+	}
+
+	if len(cfg.Resource.Attributes) > 0 {
+		attrs := make([]attribute.KeyValue, 0, len(cfg.Resource.Attributes))
+		for key, value := range cfg.Resource.Attributes {
+			attrs = append(attrs, attribute.String(key, value))
+		}
+		opts = append(opts, resource.WithAttributes(attrs...))
+	}
+
+	// Later options take precedence
+	opts = append(opts, resource.WithAttributes(
+		semconv.ServiceNameKey.String(cfg.ServiceName),
+		semconv.ServiceVersionKey.String(cfg.ServiceVersion),
+		semconv.DeploymentEnvironmentKey.String(cfg.Environment),
+	))
+
+	res, err := resource.New(ctx, opts...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	// A partial resource still carries the attributes that could be detected
+	return res, nil
+}
+
+// containerDetector reads the container ID from the cgroup of the process (cgroup v1), then
+// from its mounts (cgroup v2, where the cgroup path is usually "/")
+// Under cgroup v2, the ID is found for Docker and Podman containers; containerd and CRI-O
+// (e.g., Kubernetes) mount no per-container directory, so no ID is detected there.
+type containerDetector struct{}
+
+// Detect returns the container ID found, or an empty resource
+func (containerDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	res, err := resource.New(ctx, resource.WithContainerID())
+	if err != nil {
+		return nil, err
+	}
+	if _, found := res.Set().Value(semconv.ContainerIDKey); found {
+		return res, nil
+	}
+
+	id, err := containerIDFromMountinfo(mountinfoPath)
+	if err != nil || id == "" {
+		return resource.Empty(), err
+	}
+	return resource.NewSchemaless(semconv.ContainerID(id)), nil
+}
+
+// containerIDFromMountinfo returns the container ID found in a mountinfo file, or "" when the
+// file does not exist or holds none
+func containerIDFromMountinfo(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if matches := mountinfoContainerIDRe.FindSubmatch(data); matches != nil {
+		return string(matches[1]), nil
+	}
+	return "", nil
+}
+
+// kubernetesDetector reads the pod identity exposed through downward API environment variables
+type kubernetesDetector struct{}
+
+// Detect returns the pod attributes found in the environment
+// The resource has no schema URL, so it merges with the SDK detectors whatever their version.
+func (kubernetesDetector) Detect(context.Context) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	if value := lookupEnv(kubernetesPodNameEnv); value != "" {
+		attrs = append(attrs, semconv.K8SPodName(value))
+	}
+	if value := lookupEnv(kubernetesNamespaceEnv); value != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(value))
+	}
+	if value := lookupEnv(kubernetesNodeNameEnv); value != "" {
+		attrs = append(attrs, semconv.K8SNodeName(value))
+	}
+	if value := lookupEnv(kubernetesPodUIDEnv); value != "" {
+		attrs = append(attrs, semconv.K8SPodUID(value))
+	}
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// lookupEnv returns the value of the first variable set
+func lookupEnv(names []string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
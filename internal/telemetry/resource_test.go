@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContainerIDFromMountinfo(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name      string
+		mountinfo string
+		want      string
+	}{
+		{"docker", "610 590 259:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw,relatime - ext4 /dev/root rw", id},
+		{"podman", "773 750 0:44 /containers/storage/overlay-containers/" + id + "/userdata/hostname /etc/hostname rw - tmpfs tmpfs rw", id},
+		{"containerd sandbox", "901 880 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/" + id + "/hostname /etc/hostname rw - ext4 /dev/root rw", ""},
+		{"host", "25 1 259:1 / / rw,relatime shared:1 - ext4 /dev/root rw", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mountinfo")
+			if err := os.WriteFile(path, []byte(tt.mountinfo+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := containerIDFromMountinfo(path)
+			if err != nil || got != tt.want {
+				t.Errorf("containerIDFromMountinfo() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if got, err := containerIDFromMountinfo(filepath.Join(t.TempDir(), "missing")); err != nil || got != "" {
+		t.Errorf("missing file: containerIDFromMountinfo() = %q, %v, want no ID", got, err)
+	}
+}
+
+func TestResourceConfigRejectsServiceAttributes(t *testing.T) {
+	for _, key := range []string{"service.name", "service.version", "deployment.environment"} {
+		err := ResourceConfig{Attributes: map[string]string{key: "value"}}.Validate()
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Validate() with %s = %v, want an error", key, err)
+		}
+	}
+	if err := (ResourceConfig{Attributes: map[string]string{"service.namespace": "shop"}}).Validate(); err != nil {
+		t.Errorf("Validate() with service.namespace = %v, want nil", err)
+	}
+}
@@ -0,0 +1,268 @@
+package telemetry
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxPendingTraces bounds the unsampled traces buffered by error sampling
+// When exceeded, the oldest trace is dropped.
+const maxPendingTraces = 4096
+
+// SamplingConfig configures trace sampling
+type SamplingConfig struct {
+	Ratio        float64        // Ratio of sampled traces, between 0 and 1
+	ParentBased  bool           // Follow the sampling decision of a remote parent (traceparent flags)
+	Rules        []SamplingRule // Per-route rules, first match wins; they take precedence over the parent decision
+	SampleErrors bool           // Keep unsampled traces whose spans end with an error status
+}
+
+// SamplingRule sets the sampling ratio of the requests of a route
+type SamplingRule struct {
+	// Method restricts the rule to an HTTP method (empty: every method)
+	Method string
+
+	// Route is a full route template as registered (e.g., "/api/v1/payments/:id", including
+	// BasePath), or a prefix ending with "*" (e.g., "/api/v1/payments/*")
+	Route string
+
+	// Ratio is the ratio of sampled requests: 0 never samples, 1 always samples
+	Ratio float64
+}
+
+// Validate checks the ratios and rules
+func (c SamplingConfig) Validate() error {
+	if c.Ratio < 0 || c.Ratio > 1 {
+		return fmt.Errorf("sample ratio must be between 0 and 1, got %v", c.Ratio)
+	}
+	for i, rule := range c.Rules {
+		if !strings.HasPrefix(rule.Route, "/") {
+			return fmt.Errorf("sampling rule %d: route '%s' must start with '/'", i, rule.Route)
+		}
+		if strings.Contains(strings.TrimSuffix(rule.Route, "*"), "*") {
+			return fmt.Errorf("sampling rule %d: route '%s' may only end with '*'", i, rule.Route)
+		}
+		if rule.Method != "" && rule.Method != strings.ToUpper(rule.Method) {
+			return fmt.Errorf("sampling rule %d: method '%s' must be uppercase", i, rule.Method)
+		}
+		if rule.Ratio < 0 || rule.Ratio > 1 {
+			return fmt.Errorf("sampling rule %d: ratio must be between 0 and 1, got %v", i, rule.Ratio)
+		}
+	}
+	return nil
+}
+
+// routeSampler samples root spans by route rules, remote parent decision and ratio
+// Child spans follow their local parent, so traces are never partially sampled.
+type routeSampler struct {
+	cfg   SamplingConfig
+	ratio sdktrace.Sampler
+	rules []sdktrace.Sampler // ratio sampler of each rule
+}
+
+// newSampler creates the sampler of the configuration
+func newSampler(cfg SamplingConfig) sdktrace.Sampler {
+	s := &routeSampler{
+		cfg:   cfg,
+		ratio: sdktrace.TraceIDRatioBased(cfg.Ratio),
+	}
+	for _, rule := range cfg.Rules {
+		s.rules = append(s.rules, sdktrace.TraceIDRatioBased(rule.Ratio))
+	}
+	return s
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s *routeSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+
+	var sampled bool
+	if parent.IsValid() && !parent.IsRemote() {
+		sampled = parent.IsSampled()
+	} else {
+		sampled = s.sampleRoot(p, parent)
+	}
+
+	result := sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: parent.TraceState(),
+	}
+	if sampled {
+		result.Decision = sdktrace.RecordAndSample
+	} else if s.cfg.SampleErrors {
+		// Recorded but not sampled: the error sampling processor decides when the trace ends
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+// sampleRoot decides for a span without local parent (the request span)
+func (s *routeSampler) sampleRoot(p sdktrace.SamplingParameters, parent trace.SpanContext) bool {
+	if len(s.rules) > 0 {
+		method, route := requestRoute(p.Attributes)
+		for i, rule := range s.cfg.Rules {
+			if matchRule(rule, method, route) {
+				return s.rules[i].ShouldSample(p).Decision == sdktrace.RecordAndSample
+			}
+		}
+	}
+
+	if parent.IsValid() && s.cfg.ParentBased {
+		return parent.IsSampled()
+	}
+	return s.ratio.ShouldSample(p).Decision == sdktrace.RecordAndSample
+}
+
+// Description implements sdktrace.Sampler
+func (s *routeSampler) Description() string {
+	return fmt.Sprintf("RouteSampler{ratio=%g,rules=%d,parentBased=%t,errors=%t}",
+		s.cfg.Ratio, len(s.cfg.Rules), s.cfg.ParentBased, s.cfg.SampleErrors)
+}
+
+// requestRoute returns the method and route of a server span from its start attributes
+// The route template is used when the request matched a route, the raw path otherwise.
+func requestRoute(attrs []attribute.KeyValue) (method, route string) {
+	var path string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case semconv.HTTPRequestMethodKey:
+			method = attr.Value.AsString()
+		case semconv.HTTPRouteKey:
+			route = attr.Value.AsString()
+		case semconv.URLPathKey:
+			path = attr.Value.AsString()
+		}
+	}
+	if route == "" {
+		route = path
+	}
+	return method, route
+}
+
+// matchRule reports whether a rule applies to a request
+func matchRule(rule SamplingRule, method, route string) bool {
+	if route == "" {
+		return false
+	}
+	if rule.Method != "" && rule.Method != method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(rule.Route, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return route == rule.Route
+}
+
+// errorSamplingProcessor buffers the spans of unsampled traces until their local root span
+// ends, then exports them only when one of them ended with an error status
+// Sampled spans are passed through unchanged.
+type errorSamplingProcessor struct {
+	next sdktrace.SpanProcessor
+
+	mu      sync.Mutex
+	pending map[trace.TraceID]*pendingTrace
+	order   *list.List // trace IDs, oldest first
+	max     int
+}
+
+// pendingTrace holds the ended spans of an unsampled trace
+type pendingTrace struct {
+	spans  []sdktrace.ReadOnlySpan
+	failed bool
+	elem   *list.Element
+}
+
+// sampledSpan marks a span of a failed trace as sampled, so the exporter keeps it
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+	sc trace.SpanContext
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+// newErrorSamplingProcessor wraps the processor exporting spans
+func newErrorSamplingProcessor(next sdktrace.SpanProcessor, maxTraces int) *errorSamplingProcessor {
+	return &errorSamplingProcessor{
+		next:    next,
+		pending: make(map[trace.TraceID]*pendingTrace),
+		order:   list.New(),
+		max:     maxTraces,
+	}
+}
+
+// OnStart implements sdktrace.SpanProcessor
+func (p *errorSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd implements sdktrace.SpanProcessor
+func (p *errorSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sc := s.SpanContext()
+	if sc.IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+
+	// The local root span ends last: decide for the whole trace
+	localRoot := !s.Parent().IsValid() || s.Parent().IsRemote()
+
+	traceID := sc.TraceID()
+	p.mu.Lock()
+	pt, ok := p.pending[traceID]
+	switch {
+	case ok && localRoot:
+		delete(p.pending, traceID)
+		p.order.Remove(pt.elem)
+	case !ok && localRoot:
+		// Nothing buffered (no child span, or the trace was evicted): buffering the
+		// root span would only evict another trace
+		pt = &pendingTrace{}
+	case !ok:
+		if p.order.Len() >= p.max {
+			oldest := p.order.Front()
+			delete(p.pending, oldest.Value.(trace.TraceID))
+			p.order.Remove(oldest)
+		}
+		pt = &pendingTrace{elem: p.order.PushBack(traceID)}
+		p.pending[traceID] = pt
+	}
+	pt.spans = append(pt.spans, s)
+	if s.Status().Code == codes.Error {
+		pt.failed = true
+	}
+	p.mu.Unlock()
+
+	if localRoot && pt.failed {
+		for _, span := range pt.spans {
+			p.next.OnEnd(sampledSpan{
+				ReadOnlySpan: span,
+				sc:           span.SpanContext().WithTraceFlags(span.SpanContext().TraceFlags().WithSampled(true)),
+			})
+		}
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor
+func (p *errorSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.pending = make(map[trace.TraceID]*pendingTrace)
+	p.order.Init()
+	p.mu.Unlock()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor
+func (p *errorSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
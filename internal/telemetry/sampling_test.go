@@ -0,0 +1,162 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRouteSamplerDecisions(t *testing.T) {
+	rules := []SamplingRule{
+		{Method: "POST", Route: "/api/v1/payments/:id", Ratio: 1},
+		{Route: "/api/v1/payments/*", Ratio: 0},
+		{Route: "/health", Ratio: 0},
+	}
+	parent := func(remote, sampled bool) context.Context {
+		var flags trace.TraceFlags
+		if sampled {
+			flags = trace.FlagsSampled
+		}
+		return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: flags, Remote: remote,
+		}))
+	}
+	request := func(method, route, path string) []attribute.KeyValue {
+		attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method), semconv.URLPath(path)}
+		if route != "" {
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		return attrs
+	}
+
+	tests := []struct {
+		name  string
+		cfg   SamplingConfig
+		ctx   context.Context
+		attrs []attribute.KeyValue
+		want  sdktrace.SamplingDecision
+	}{
+		{"rule matches method and route", SamplingConfig{Rules: rules},
+			context.Background(), request("POST", "/api/v1/payments/:id", "/api/v1/payments/7"), sdktrace.RecordAndSample},
+		{"rule order and prefix", SamplingConfig{Ratio: 1, Rules: rules},
+			context.Background(), request("GET", "/api/v1/payments/:id", "/api/v1/payments/7"), sdktrace.Drop},
+		{"raw path without route", SamplingConfig{Ratio: 1, Rules: rules},
+			context.Background(), request("GET", "", "/health"), sdktrace.Drop},
+		{"no rule uses the ratio", SamplingConfig{Ratio: 1, Rules: rules},
+			context.Background(), request("GET", "/orders", "/orders"), sdktrace.RecordAndSample},
+		{"remote parent followed", SamplingConfig{ParentBased: true},
+			parent(true, true), request("GET", "/orders", "/orders"), sdktrace.RecordAndSample},
+		{"remote parent ignored", SamplingConfig{},
+			parent(true, true), request("GET", "/orders", "/orders"), sdktrace.Drop},
+		{"rules win over remote parent", SamplingConfig{ParentBased: true, Rules: rules},
+			parent(true, true), request("GET", "/health", "/health"), sdktrace.Drop},
+		{"local parent followed", SamplingConfig{Ratio: 1, Rules: rules},
+			parent(false, false), request("POST", "/api/v1/payments/:id", "/api/v1/payments/7"), sdktrace.Drop},
+		{"unsampled recorded for error sampling", SamplingConfig{SampleErrors: true},
+			context.Background(), request("GET", "/orders", "/orders"), sdktrace.RecordOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newSampler(tt.cfg).ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: tt.ctx,
+				TraceID:       trace.TraceID{1},
+				Name:          "request",
+				Kind:          trace.SpanKindServer,
+				Attributes:    tt.attrs,
+			})
+			if result.Decision != tt.want {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.want)
+			}
+		})
+	}
+}
+
+// newErrorSamplingProvider creates a provider sampling no trace, keeping failed ones
+func newErrorSamplingProvider(next sdktrace.SpanProcessor, maxTraces int) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(newSampler(SamplingConfig{SampleErrors: true})),
+		sdktrace.WithSpanProcessor(newErrorSamplingProcessor(next, maxTraces)),
+	)
+}
+
+func TestErrorSamplingExportsFailedTraces(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := newErrorSamplingProvider(sdktrace.NewSimpleSpanProcessor(exporter), maxPendingTraces).Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "request")
+	_, child := tracer.Start(ctx, "query")
+	child.SetStatus(codes.Error, "connection reset")
+	child.End()
+	if spans := exporter.GetSpans(); len(spans) != 0 {
+		t.Fatalf("exported %d spans before the root span ended, want 0", len(spans))
+	}
+
+	root.End()
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want the 2 spans of the failed trace", len(spans))
+	}
+	for _, span := range spans {
+		if !span.SpanContext.IsSampled() {
+			t.Errorf("span %s exported unsampled", span.Name)
+		}
+	}
+
+	exporter.Reset()
+	ctx, root = tracer.Start(context.Background(), "request")
+	_, child = tracer.Start(ctx, "query")
+	child.End()
+	root.End()
+	if spans := exporter.GetSpans(); len(spans) != 0 {
+		t.Errorf("exported %d spans of a successful unsampled trace, want 0", len(spans))
+	}
+}
+
+func TestErrorSamplingEvictsOldestTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := newErrorSamplingProvider(sdktrace.NewSimpleSpanProcessor(exporter), 1).Tracer("test")
+
+	oldCtx, oldRoot := tracer.Start(context.Background(), "old request")
+	_, oldChild := tracer.Start(oldCtx, "query")
+	oldChild.SetStatus(codes.Error, "timeout")
+	oldChild.End()
+
+	newCtx, newRoot := tracer.Start(context.Background(), "new request")
+	_, newChild := tracer.Start(newCtx, "query")
+	newChild.SetStatus(codes.Error, "timeout")
+	newChild.End()
+
+	oldRoot.End()
+	if spans := exporter.GetSpans(); len(spans) != 0 {
+		t.Errorf("exported %d spans of an evicted trace, want 0", len(spans))
+	}
+	newRoot.End()
+	if spans := exporter.GetSpans(); len(spans) != 2 {
+		t.Errorf("exported %d spans of the buffered trace, want 2", len(spans))
+	}
+}
+
+func TestErrorSamplingFlushesFailedTraces(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := newErrorSamplingProvider(sdktrace.NewBatchSpanProcessor(exporter, sdktrace.WithBatchTimeout(time.Hour)), maxPendingTraces)
+
+	_, root := provider.Tracer("test").Start(context.Background(), "request")
+	root.SetStatus(codes.Error, "panic")
+	root.End()
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if spans := exporter.GetSpans(); len(spans) != 1 {
+		t.Errorf("exported %d spans after flush, want the failed root span", len(spans))
+	}
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+}
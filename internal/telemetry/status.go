@@ -0,0 +1,196 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// maxQueueSize bounds the spans waiting for export; further spans are dropped and counted
+const maxQueueSize = 2048
+
+// ExportStatus reports the activity of the span export pipeline
+type ExportStatus struct {
+	SpansExported       uint64    // Spans accepted by the exporter
+	SpansFailed         uint64    // Spans lost in failed exports (after the exporter retries)
+	SpansDropped        uint64    // Spans dropped because the export queue was full
+	ConsecutiveFailures int       // Failed exports since the last successful one
+	LastExport          time.Time // Time of the last successful export (zero if none)
+	LastError           string    // Error of the last failed export
+	LastErrorTime       time.Time // Time of the last failed export (zero if none)
+}
+
+// exportStats counts the spans going through the export pipeline
+type exportStats struct {
+	exported atomic.Uint64
+	failed   atomic.Uint64
+	dropped  atomic.Uint64
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastExport          time.Time
+	lastError           string
+	lastErrorTime       time.Time
+}
+
+// recordExport records the result of an export of n spans
+func (s *exportStats) recordExport(n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.failed.Add(uint64(n))
+		s.consecutiveFailures++
+		s.lastError = err.Error()
+		s.lastErrorTime = time.Now()
+		return
+	}
+	s.exported.Add(uint64(n))
+	s.consecutiveFailures = 0
+	s.lastExport = time.Now()
+}
+
+// status returns a snapshot of the counters
+func (s *exportStats) status() ExportStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ExportStatus{
+		SpansExported:       s.exported.Load(),
+		SpansFailed:         s.failed.Load(),
+		SpansDropped:        s.dropped.Load(),
+		ConsecutiveFailures: s.consecutiveFailures,
+		LastExport:          s.lastExport,
+		LastError:           s.lastError,
+		LastErrorTime:       s.lastErrorTime,
+	}
+}
+
+// countingExporter records the result of every export
+type countingExporter struct {
+	sdktrace.SpanExporter
+	stats *exportStats
+}
+
+// ExportSpans implements sdktrace.SpanExporter
+func (e countingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.stats.recordExport(len(spans), err)
+	return err
+}
+
+// queueProcessor queues ended spans for a blocking batch processor, dropping and counting
+// spans when the queue is full (the batch processor does not expose its own drop count)
+type queueProcessor struct {
+	next  sdktrace.SpanProcessor
+	stats *exportStats
+	queue chan sdktrace.ReadOnlySpan
+	flush chan chan struct{} // drain requests, acknowledged once the queue is empty
+	done  chan struct{}      // closed when the queue is drained after shutdown
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// newQueueProcessor wraps the processor exporting spans
+func newQueueProcessor(next sdktrace.SpanProcessor, stats *exportStats, size int) *queueProcessor {
+	p := &queueProcessor{
+		next:  next,
+		stats: stats,
+		queue: make(chan sdktrace.ReadOnlySpan, size),
+		flush: make(chan chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// run forwards queued spans to the next processor until the queue is closed
+func (p *queueProcessor) run() {
+	defer close(p.done)
+	for {
+		select {
+		case s, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			p.next.OnEnd(s)
+		case ack := <-p.flush:
+			p.drain()
+			close(ack)
+		}
+	}
+}
+
+// drain forwards the spans currently queued
+func (p *queueProcessor) drain() {
+	for {
+		select {
+		case s, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			p.next.OnEnd(s)
+		default:
+			return
+		}
+	}
+}
+
+// OnStart implements sdktrace.SpanProcessor
+func (p *queueProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd implements sdktrace.SpanProcessor
+func (p *queueProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.queue <- s:
+	default:
+		p.stats.dropped.Add(1)
+	}
+}
+
+// Shutdown implements sdktrace.SpanProcessor
+// Queued spans are forwarded before the next processor shuts down, within ctx.
+func (p *queueProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+	}
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor
+func (p *queueProcessor) ForceFlush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case p.flush <- ack:
+		select {
+		case <-ack:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.next.ForceFlush(ctx)
+}
@@ -2,20 +2,29 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 // TelemetryManager manages the OpenTelemetry lifecycle
 type TelemetryManager struct {
 	tp       *sdktrace.TracerProvider
+	mp       *sdkmetric.MeterProvider // nil when metrics are disabled
+	lp       *sdklog.LoggerProvider   // nil when logs are disabled
+	file     *os.File                 // output of the file exporter, closed after the providers
+	stats    *exportStats             // span export counters
 	shutdown func(context.Context) error
 }
 
@@ -24,51 +33,117 @@ type Config struct {
 	ServiceName    string
 	ServiceVersion string
 	Environment    string
-	OTLPEndpoint   string
-	SampleAll      bool
+	Sampling       SamplingConfig
+
+	Exporter ExporterConfig // Exporter of traces, metrics and logs
+
+	Metrics         bool          // Export OTel metrics alongside traces
+	MetricsInterval time.Duration // Export interval of metrics (default: 60s)
+
+	Logs bool // Export OTel log records emitted through the global logger provider
+
+	Resource ResourceConfig // Detectors and additional attributes of the resource
+
+	IDGenerator sdktrace.IDGenerator // Generates trace and span IDs (default: random)
+
+	// Probe sends an empty trace export request when initializing, so an unreachable collector
+	// or a rejected export fails Init with ErrProbeFailed (OTLP exporter only)
+	Probe bool
 }
 
 // Init initializes OpenTelemetry with OTLP exporter to Datadog Agent
 // Returns a TelemetryManager that should be shut down on application exit
 func Init(ctx context.Context, cfg Config) (*TelemetryManager, error) {
-	// Create resource with service information
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(cfg.ServiceName),
-			semconv.ServiceVersionKey.String(cfg.ServiceVersion),
-			semconv.DeploymentEnvironmentKey.String(cfg.Environment),
-		),
-	)
+	// Create resource with service information and detected attributes
+	res, err := newResource(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
-	// Create OTLP HTTP exporter to Datadog Agent
-	exporter, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithEndpoint(cfg.OTLPEndpoint),
-		otlptracehttp.WithInsecure(), // Agent is typically local, no TLS needed
-	)
+	if err := cfg.Exporter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Sampling.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Stdout and file exporters share one writer
+	var out io.Writer = os.Stdout
+	var file *os.File
+	if cfg.Exporter.Exporter == ExporterFile {
+		file, err = openExportFile(cfg.Exporter.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		out = file
+	}
+
+	// Create span exporter (OTLP to the Datadog Agent by default)
+	exporter, err := newTraceExporter(ctx, cfg.Exporter, out)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		closeFile(file)
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
 	}
 
-	// Determine sampler
-	var sampler sdktrace.Sampler
-	if cfg.SampleAll {
-		sampler = sdktrace.AlwaysSample()
-	} else {
-		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.1)) // Sample 10% by default
+	// Configuration errors come first: only the probe failure is worth retrying
+	if cfg.Probe && (cfg.Exporter.Exporter == "" || cfg.Exporter.Exporter == ExporterOTLP) {
+		if err := probeExporter(ctx, exporter, cfg.Exporter.Timeout); err != nil {
+			_ = exporter.Shutdown(ctx)
+			return nil, err
+		}
 	}
 
-	// Create trace provider with batch span processor
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter,
+	// Create batch span processor behind a counting queue, so exports and dropped spans are
+	// reported by Status; with error sampling, unsampled traces are buffered until they end
+	// and exported only when they failed
+	stats := &exportStats{}
+	var processor sdktrace.SpanProcessor = newQueueProcessor(
+		sdktrace.NewBatchSpanProcessor(countingExporter{SpanExporter: exporter, stats: stats},
 			sdktrace.WithBatchTimeout(5*time.Second),
 			sdktrace.WithMaxExportBatchSize(512),
+			sdktrace.WithBlocking(),
 		),
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sampler),
+		stats, maxQueueSize,
 	)
+	if cfg.Sampling.SampleErrors {
+		processor = newErrorSamplingProcessor(processor, maxPendingTraces)
+	}
+
+	// Create trace provider sampling by route rules, parent decision and ratio
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(newSampler(cfg.Sampling)),
+	}
+	if cfg.IDGenerator != nil {
+		tpOpts = append(tpOpts, sdktrace.WithIDGenerator(cfg.IDGenerator))
+	}
+	tp := sdktrace.NewTracerProvider(tpOpts...)
+
+	// Create meter provider with periodic OTLP export
+	var mp *sdkmetric.MeterProvider
+	if cfg.Metrics {
+		mp, err = newMeterProvider(ctx, cfg, res, out)
+		if err != nil {
+			_ = tp.Shutdown(ctx)
+			closeFile(file)
+			return nil, err
+		}
+	}
+
+	// Create logger provider with batched OTLP export
+	var lp *sdklog.LoggerProvider
+	if cfg.Logs {
+		lp, err = newLoggerProvider(ctx, cfg, res, out)
+		if err != nil {
+			_ = tp.Shutdown(ctx)
+			if mp != nil {
+				_ = mp.Shutdown(ctx)
+			}
+			closeFile(file)
+			return nil, err
+		}
+	}
 
 	// Set global trace provider and propagator
 	otel.SetTracerProvider(tp)
@@ -77,10 +152,98 @@ func Init(ctx context.Context, cfg Config) (*TelemetryManager, error) {
 		propagation.Baggage{},
 	))
 
-	return &TelemetryManager{
-		tp:       tp,
-		shutdown: tp.Shutdown,
-	}, nil
+	// Set global meter provider (used by the Telemetry middleware for HTTP server metrics)
+	if mp != nil {
+		otel.SetMeterProvider(mp)
+	}
+
+	// Set global logger provider (used by loggers bridging to OTel logs, even those created before Init)
+	if lp != nil {
+		global.SetLoggerProvider(lp)
+	}
+
+	tm := &TelemetryManager{tp: tp, mp: mp, lp: lp, file: file, stats: stats}
+	tm.shutdown = tm.shutdownProviders
+	return tm, nil
+}
+
+// newMeterProvider creates a meter provider with periodic export
+func newMeterProvider(ctx context.Context, cfg Config, res *resource.Resource, out io.Writer) (*sdkmetric.MeterProvider, error) {
+	exporter, err := newMetricExporter(ctx, cfg.Exporter, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics exporter: %w", err)
+	}
+
+	interval := cfg.MetricsInterval
+	if interval <= 0 {
+		interval = 60 * time.Second
+	}
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
+		sdkmetric.WithResource(res),
+	), nil
+}
+
+// newLoggerProvider creates a logger provider with batched export
+func newLoggerProvider(ctx context.Context, cfg Config, res *resource.Resource, out io.Writer) (*sdklog.LoggerProvider, error) {
+	exporter, err := newLogExporter(ctx, cfg.Exporter, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logs exporter: %w", err)
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	), nil
+}
+
+// shutdownProviders flushes and shuts down every provider, even when one fails
+func (tm *TelemetryManager) shutdownProviders(ctx context.Context) error {
+	var errs []error
+	if err := tm.tp.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracer provider: %w", err))
+	}
+	if tm.mp != nil {
+		if err := tm.mp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("meter provider: %w", err))
+		}
+	}
+	if tm.lp != nil {
+		if err := tm.lp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("logger provider: %w", err))
+		}
+	}
+	if tm.file != nil {
+		if err := tm.file.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("export file: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Meter returns a meter of the telemetry meter provider for custom instruments
+// Without metrics, the meter of the global provider is returned (a no-op until one is set).
+func (tm *TelemetryManager) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	if tm == nil || tm.mp == nil {
+		return otel.GetMeterProvider().Meter(name, opts...)
+	}
+	return tm.mp.Meter(name, opts...)
+}
+
+// Status reports the spans exported, failed and dropped since Init
+func (tm *TelemetryManager) Status() ExportStatus {
+	if tm == nil || tm.stats == nil {
+		return ExportStatus{}
+	}
+	return tm.stats.status()
+}
+
+// closeFile closes the output of the file exporter after a failed initialization
+func closeFile(file *os.File) {
+	if file != nil {
+		_ = file.Close()
+	}
 }
 
 // Shutdown gracefully shuts down the telemetry provider
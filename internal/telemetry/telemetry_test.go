@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitValidatesSamplingBeforeExporting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	_, err := Init(context.Background(), Config{
+		ServiceName: "orders",
+		Exporter:    ExporterConfig{Exporter: ExporterFile, FilePath: path},
+		Sampling:    SamplingConfig{Ratio: 2},
+	})
+	if err == nil {
+		t.Fatal("Init() accepted a sample ratio of 2")
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("export file created before the configuration was validated: %v", statErr)
+	}
+}
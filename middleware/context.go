@@ -1,9 +1,6 @@
 package middleware
 
 import (
-	"context"
-	"time"
-
 	"github.com/gin-gonic/gin"
 )
 
@@ -64,42 +61,3 @@ func IsContextCancelled(c *gin.Context) bool {
 func GetContextError(c *gin.Context) error {
 	return c.Request.Context().Err()
 }
-
-// WithTimeout wraps a handler with a timeout using context.WithTimeout
-// If the handler doesn't complete within the timeout, it returns 408 Request Timeout.
-//
-// Example:
-//
-//	// Set 5 second timeout for this specific endpoint
-//	router.GET("/slow-endpoint", middleware.WithTimeout(5*time.Second), handler)
-//
-// Note: This is useful for specific endpoints that need stricter timeouts than the global server timeout.
-func WithTimeout(timeout time.Duration) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Create context with timeout
-		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
-		defer cancel()
-
-		// Replace request context
-		c.Request = c.Request.WithContext(ctx)
-
-		// Channel to signal completion
-		done := make(chan struct{})
-
-		go func() {
-			c.Next()
-			close(done)
-		}()
-
-		select {
-		case <-done:
-			// Handler completed successfully
-			return
-		case <-ctx.Done():
-			// Timeout exceeded
-			c.Error(ctx.Err())
-			c.Abort()
-			return
-		}
-	}
-}
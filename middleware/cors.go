@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -9,12 +12,57 @@ import (
 
 // CORSConfig holds CORS configuration
 type CORSConfig struct {
+	// AllowedOrigins accepts exact origins, "*", wildcard subdomains ("https://*.example.com")
+	// and regular expressions prefixed with "regex:" (see OriginPolicy)
 	AllowedOrigins   []string
 	AllowedMethods   []string
 	AllowedHeaders   []string
 	ExposedHeaders   []string
 	AllowCredentials bool
 	MaxAge           time.Duration
+
+	// AllowOriginFunc is consulted when an origin does not match AllowedOrigins
+	// It receives the request context, e.g. to resolve the tenant from the host
+	AllowOriginFunc func(c *gin.Context, origin string) bool
+
+	// Origins is an optional pre-built policy shared with the caller so the
+	// allowed origins can be reloaded at runtime. When nil, a policy is built
+	// from AllowedOrigins.
+	Origins *OriginPolicy
+}
+
+// CORSMiddleware handles CORS for the whole router and supports per-path overrides
+// Overrides are resolved by the longest matching path prefix, so preflight requests
+// for routes inside a group are answered with the group's policy even though
+// no OPTIONS route is registered for them.
+type CORSMiddleware struct {
+	base      CORSConfig
+	handler   *corsHandler
+	mu        sync.RWMutex
+	overrides []corsOverride
+}
+
+// corsOverride binds a path prefix to a group-specific CORS handler
+type corsOverride struct {
+	prefix  string
+	handler *corsHandler
+}
+
+// corsHandler applies a single CORS configuration backed by an OriginPolicy
+type corsHandler struct {
+	origins    *OriginPolicy
+	allowAll   gin.HandlerFunc // used while the policy contains "*"
+	restricted gin.HandlerFunc // used for explicit origins and patterns
+}
+
+// NewCORS creates a CORS middleware with the given base configuration
+// It panics if AllowedOrigins contains a malformed pattern; use ValidateOrigins
+// (or Config.Validate) to check configuration beforehand.
+func NewCORS(cfg CORSConfig) *CORSMiddleware {
+	return &CORSMiddleware{
+		base:    cfg,
+		handler: newCORSHandler(cfg),
+	}
 }
 
 // CORS creates a CORS middleware with the given configuration
@@ -22,8 +70,119 @@ type CORSConfig struct {
 // credentials (cookies, HTTP auth) cannot be allowed. This middleware enforces
 // this requirement by automatically setting AllowCredentials to false when "*" is used.
 func CORS(cfg CORSConfig) gin.HandlerFunc {
-	// Check if wildcard is requested
-	allowAllOrigins := len(cfg.AllowedOrigins) == 1 && cfg.AllowedOrigins[0] == "*"
+	return NewCORS(cfg).Handler()
+}
+
+// Handler returns the gin middleware function
+func (m *CORSMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m.resolve(c.Request.URL.Path).serve(c)
+	}
+}
+
+// Origins returns the origin policy of the base configuration
+// Use it to reload the allowed origins at runtime
+func (m *CORSMiddleware) Origins() *OriginPolicy {
+	return m.handler.origins
+}
+
+// Override applies a different CORS configuration to every path under pathPrefix
+// pathPrefix may contain route parameters (e.g., "/tenants/:id").
+// Empty methods, headers, exposed headers and max age are inherited from the base configuration.
+func (m *CORSMiddleware) Override(pathPrefix string, cfg CORSConfig) {
+	if cfg.AllowedMethods == nil {
+		cfg.AllowedMethods = m.base.AllowedMethods
+	}
+	if cfg.AllowedHeaders == nil {
+		cfg.AllowedHeaders = m.base.AllowedHeaders
+	}
+	if cfg.ExposedHeaders == nil {
+		cfg.ExposedHeaders = m.base.ExposedHeaders
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = m.base.MaxAge
+	}
+
+	prefix := strings.TrimSuffix(pathPrefix, "/")
+	override := corsOverride{prefix: prefix, handler: newCORSHandler(cfg)}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Replace an existing override for the same prefix
+	for i, o := range m.overrides {
+		if o.prefix == prefix {
+			m.overrides[i] = override
+			return
+		}
+	}
+
+	m.overrides = append(m.overrides, override)
+	// Keep the most specific prefixes first: more segments, then fewer parameters
+	sort.SliceStable(m.overrides, func(i, j int) bool {
+		a, b := m.overrides[i].prefix, m.overrides[j].prefix
+		if sa, sb := strings.Count(a, "/"), strings.Count(b, "/"); sa != sb {
+			return sa > sb
+		}
+		return strings.Count(a, ":")+strings.Count(a, "*") < strings.Count(b, ":")+strings.Count(b, "*")
+	})
+}
+
+// resolve returns the handler whose prefix best matches the request path
+// Prefixes are route templates: ":name" segments match any segment and a "*name" segment
+// matches the rest of the path, so groups such as "/tenants/:id" apply to preflight requests
+// too, which match no registered route.
+func (m *CORSMiddleware) resolve(path string) *corsHandler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, o := range m.overrides {
+		if matchPathPrefix(o.prefix, path) {
+			return o.handler
+		}
+	}
+
+	return m.handler
+}
+
+// matchPathPrefix reports whether the route template prefix matches the beginning of path,
+// segment by segment
+func matchPathPrefix(prefix, path string) bool {
+	if prefix == "" {
+		return true
+	}
+	if !strings.ContainsAny(prefix, ":*") {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+
+	pathSegments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range strings.Split(strings.TrimPrefix(prefix, "/"), "/") {
+		switch {
+		case strings.HasPrefix(segment, "*"):
+			return true
+		case i >= len(pathSegments):
+			return false
+		case strings.HasPrefix(segment, ":"):
+			if pathSegments[i] == "" {
+				return false
+			}
+		case segment != pathSegments[i]:
+			return false
+		}
+	}
+	return true
+}
+
+// newCORSHandler builds the gin-contrib handlers for a configuration
+func newCORSHandler(cfg CORSConfig) *corsHandler {
+	origins := cfg.Origins
+	if origins == nil {
+		var err error
+		origins, err = NewOriginPolicy(cfg.AllowedOrigins)
+		if err != nil {
+			panic("cors: " + err.Error())
+		}
+	}
 
 	config := cors.Config{
 		AllowMethods:     cfg.AllowedMethods,
@@ -33,14 +192,32 @@ func CORS(cfg CORSConfig) gin.HandlerFunc {
 		MaxAge:           cfg.MaxAge,
 	}
 
-	if allowAllOrigins {
-		config.AllowAllOrigins = true
-		// CORS spec requirement: credentials cannot be used with wildcard origin
-		// This is enforced regardless of cfg.AllowCredentials value
-		config.AllowCredentials = false
-	} else {
-		config.AllowOrigins = cfg.AllowedOrigins
+	allowAllConfig := config
+	allowAllConfig.AllowAllOrigins = true
+	// CORS spec requirement: credentials cannot be used with wildcard origin
+	// This is enforced regardless of cfg.AllowCredentials value
+	allowAllConfig.AllowCredentials = false
+
+	restrictedConfig := config
+	restrictedConfig.AllowOriginWithContextFunc = func(c *gin.Context, origin string) bool {
+		if origins.Allow(origin) {
+			return true
+		}
+		return cfg.AllowOriginFunc != nil && cfg.AllowOriginFunc(c, origin)
 	}
 
-	return cors.New(config)
+	return &corsHandler{
+		origins:    origins,
+		allowAll:   cors.New(allowAllConfig),
+		restricted: cors.New(restrictedConfig),
+	}
+}
+
+// serve applies the CORS handler matching the current origin policy state
+func (h *corsHandler) serve(c *gin.Context) {
+	if h.origins.AllowsAll() {
+		h.allowAll(c)
+		return
+	}
+	h.restricted(c)
 }
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// RegexOriginPrefix marks an AllowedOrigins entry as a regular expression
+// The expression is always matched against the whole origin (it is implicitly anchored) and
+// ignores case, like exact and wildcard origins: schemes and hosts are case-insensitive.
+//
+// Example:
+//
+//	"regex:https://(app|admin)\\.example\\.com"
+const RegexOriginPrefix = "regex:"
+
+// OriginPolicy decides which origins are allowed to make cross-origin requests
+// It supports three pattern forms in addition to exact origins:
+//   - "*" allows every origin
+//   - "https://*.example.com" allows any subdomain of example.com over https
+//   - "regex:<expr>" allows origins fully matching the regular expression
+//
+// OriginPolicy is safe for concurrent use. The allowed origins can be replaced
+// at runtime with Reload, which is useful for multi-tenant frontends whose
+// origins are stored in a database.
+type OriginPolicy struct {
+	rules atomic.Pointer[originRules]
+}
+
+// originRules is an immutable compiled set of origin patterns
+type originRules struct {
+	allowAll  bool
+	exact     map[string]struct{}
+	wildcards []wildcardOrigin
+	regexps   []*regexp.Regexp
+}
+
+// wildcardOrigin is a compiled "scheme://*.domain[:port]" pattern
+type wildcardOrigin struct {
+	prefix string // e.g. "https://"
+	suffix string // e.g. ".example.com"
+}
+
+// NewOriginPolicy compiles the given origin patterns into a policy
+// Returns an error if any pattern is malformed
+func NewOriginPolicy(origins []string) (*OriginPolicy, error) {
+	rules, err := compileOrigins(origins)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &OriginPolicy{}
+	p.rules.Store(rules)
+	return p, nil
+}
+
+// Allow reports whether the given origin matches any of the policy patterns
+func (p *OriginPolicy) Allow(origin string) bool {
+	rules := p.rules.Load()
+	if rules.allowAll {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if _, ok := rules.exact[origin]; ok {
+		return true
+	}
+
+	for _, w := range rules.wildcards {
+		if w.match(origin) {
+			return true
+		}
+	}
+
+	for _, re := range rules.regexps {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsAll reports whether the policy currently contains the "*" wildcard
+func (p *OriginPolicy) AllowsAll() bool {
+	return p.rules.Load().allowAll
+}
+
+// Reload atomically replaces the allowed origins
+// If any pattern is malformed the current origins are kept and an error is returned
+func (p *OriginPolicy) Reload(origins []string) error {
+	rules, err := compileOrigins(origins)
+	if err != nil {
+		return err
+	}
+
+	p.rules.Store(rules)
+	return nil
+}
+
+// ValidateOrigins checks that every origin pattern is well formed
+// It accepts the same pattern forms as NewOriginPolicy
+func ValidateOrigins(origins []string) error {
+	_, err := compileOrigins(origins)
+	return err
+}
+
+// compileOrigins parses origin patterns into matchable rules
+func compileOrigins(origins []string) (*originRules, error) {
+	rules := &originRules{exact: make(map[string]struct{}, len(origins))}
+
+	for _, origin := range origins {
+		switch {
+		case origin == "*":
+			rules.allowAll = true
+
+		case strings.HasPrefix(origin, RegexOriginPrefix):
+			expr := strings.TrimPrefix(origin, RegexOriginPrefix)
+			if expr == "" {
+				return nil, fmt.Errorf("empty regex origin pattern %q", origin)
+			}
+			re, err := regexp.Compile("(?i)^(?:" + expr + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex origin pattern %q: %w", origin, err)
+			}
+			rules.regexps = append(rules.regexps, re)
+
+		case strings.Contains(origin, "*"):
+			w, err := parseWildcardOrigin(origin)
+			if err != nil {
+				return nil, err
+			}
+			rules.wildcards = append(rules.wildcards, w)
+
+		default:
+			if err := validateExactOrigin(origin); err != nil {
+				return nil, err
+			}
+			rules.exact[strings.ToLower(origin)] = struct{}{}
+		}
+	}
+
+	return rules, nil
+}
+
+// parseWildcardOrigin parses a "scheme://*.domain[:port]" pattern
+// The wildcard must be the leftmost host label and may appear only once
+func parseWildcardOrigin(origin string) (wildcardOrigin, error) {
+	scheme, rest, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" {
+		return wildcardOrigin{}, fmt.Errorf("wildcard origin %q must include a scheme (e.g. https://*.example.com)", origin)
+	}
+
+	if strings.Count(rest, "*") != 1 || !strings.HasPrefix(rest, "*.") || len(rest) <= len("*.") {
+		return wildcardOrigin{}, fmt.Errorf("wildcard origin %q must have the form scheme://*.domain", origin)
+	}
+
+	if strings.ContainsAny(rest, "/?#") {
+		return wildcardOrigin{}, fmt.Errorf("wildcard origin %q must not contain a path, query or fragment", origin)
+	}
+
+	return wildcardOrigin{
+		prefix: strings.ToLower(scheme) + "://",
+		suffix: strings.ToLower(rest[1:]),
+	}, nil
+}
+
+// validateExactOrigin checks that an exact origin has a scheme and no path
+func validateExactOrigin(origin string) error {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || host == "" {
+		return fmt.Errorf("origin %q must have the form scheme://host[:port]", origin)
+	}
+
+	if strings.ContainsAny(host, "/?#") {
+		return fmt.Errorf("origin %q must not contain a path, query or fragment", origin)
+	}
+
+	return nil
+}
+
+// match reports whether origin is a subdomain covered by the wildcard pattern
+func (w wildcardOrigin) match(origin string) bool {
+	if !strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+		return false
+	}
+
+	sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+	if sub == "" {
+		return false
+	}
+
+	// Only host characters are allowed in the subdomain part, so a pattern like
+	// https://*.example.com cannot be satisfied by https://evil.com/.example.com
+	for _, r := range sub {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' && r != '.' {
+			return false
+		}
+	}
+
+	return !strings.HasPrefix(sub, ".") && !strings.HasSuffix(sub, ".")
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import "testing"
+
+func TestOriginPolicyIgnoresCase(t *testing.T) {
+	policy, err := NewOriginPolicy([]string{
+		"https://Shop.example.com",
+		"https://*.Example.org",
+		`regex:^https://App\.example\.com$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://shop.example.com", true},
+		{"https://api.example.org", true},
+		{"https://app.example.com", true},
+		{"https://APP.example.com", true},
+		{"https://app.example.com.evil.com", false},
+	}
+	for _, tt := range tests {
+		if got := policy.Allow(tt.origin); got != tt.want {
+			t.Errorf("Allow(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
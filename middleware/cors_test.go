@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSOverrideWithRouteParameters(t *testing.T) {
+	cors := NewCORS(CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet},
+	})
+	cors.Override("/tenants/:id", CORSConfig{AllowedOrigins: []string{"https://tenant.example.com"}})
+	cors.Override("/tenants/admin", CORSConfig{AllowedOrigins: []string{"https://admin.example.com"}})
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(cors.Handler())
+	engine.GET("/tenants/:id/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		method, path, origin string
+		allowed              bool
+	}{
+		{http.MethodGet, "/tenants/42/users", "https://tenant.example.com", true},
+		{http.MethodGet, "/tenants/42/users", "https://app.example.com", false},
+		{http.MethodOptions, "/tenants/42/users", "https://tenant.example.com", true},
+		{http.MethodOptions, "/tenants/admin/users", "https://admin.example.com", true},
+		{http.MethodOptions, "/tenants/admin/users", "https://tenant.example.com", false},
+		{http.MethodGet, "/tenants", "https://app.example.com", true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("Origin", tt.origin)
+		if tt.method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin") == tt.origin; got != tt.allowed {
+			t.Errorf("%s %s from %s: allowed = %v, want %v", tt.method, tt.path, tt.origin, got, tt.allowed)
+		}
+	}
+}
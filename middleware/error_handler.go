@@ -3,11 +3,15 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"reflect"
 	"runtime/debug"
+	"slices"
 
 	platformErrors "github.com/edaniel30/http-platform-go/errors"
 	"github.com/gin-gonic/gin"
@@ -16,10 +20,35 @@ import (
 
 // ApiError represents a structured API error response
 type ApiError struct {
-	Message string `json:"message"`
-	Error   string `json:"error"`
-	Status  int    `json:"status"`
-	Cause   []any  `json:"cause,omitempty"`
+	Message string       `json:"message" xml:"message"`
+	Error   string       `json:"error" xml:"error"`
+	Status  int          `json:"status" xml:"status"`
+	Code    string       `json:"code,omitempty" xml:"code,omitempty"`
+	Details ErrorDetails `json:"details,omitempty" xml:"details,omitempty"`
+	Cause   []any        `json:"cause,omitempty" xml:"cause,omitempty"`
+
+	errorType string      // Error kind of aggregated errors, used for problem types
+	headers   http.Header // Response headers carried by the error (e.g., Retry-After)
+}
+
+// ErrorDetails is the metadata of a domain error exposed to clients
+type ErrorDetails map[string]any
+
+// MarshalXML encodes the details as one element per key, sorted by key
+// (encoding/xml cannot encode maps)
+func (d ErrorDetails) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(d) == 0 {
+		return nil
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range slices.Sorted(maps.Keys(d)) {
+		if err := e.EncodeElement(fmt.Sprint(d[key]), xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
 }
 
 // NewApiError creates a new ApiError with the given message, status code, and optional causes
@@ -34,8 +63,11 @@ func NewApiError(message string, status int, cause ...any) *ApiError {
 
 // validationError represents a single field validation error
 type validationError struct {
-	Field  string `json:"field"`
-	Reason string `json:"reason"`
+	Field   string `json:"field" xml:"field"`
+	Reason  string `json:"reason" xml:"reason"`
+	Message string `json:"message,omitempty" xml:"message,omitempty"` // Translated message, see ConfigureValidation
+
+	kind reflect.Kind // Kind of value checked by Reason, for OpenAPIValidation violations with a validator tag
 }
 
 // newValidationError creates a new validation error for a specific field
@@ -46,23 +78,55 @@ func newValidationError(field, reason string) *validationError {
 	}
 }
 
+// validationCauses lists validation errors as the causes of an ApiError, one entry per field
+func validationCauses(fields []*validationError) []any {
+	causes := make([]any, len(fields))
+	for i, field := range fields {
+		causes[i] = field
+	}
+	return causes
+}
+
 // ErrorHandler creates a middleware that handles errors and panics, converting them to appropriate HTTP responses
 // This middleware:
 // - Recovers from panics and logs them with stack traces
-// - Handles platform-specific errors (NotFound, Unauthorized, Forbidden, TooManyRequests, etc.)
-// - Handles validation errors from go-playground/validator
+// - Handles platform-specific errors (NotFound, Unauthorized, Forbidden, TooManyRequests, etc.), also when wrapped
+// - Handles validation errors from go-playground/validator and OpenAPIValidation
 // - Handles JSON parsing errors (syntax errors, type mismatches)
 // - Handles request body errors (empty body, incomplete body)
 // - Handles context cancellation (client disconnect, timeout)
 // - Logs errors with appropriate severity levels and structured fields
+// - Records errors as span exception events (error_type, status, panic stack) and fails the span on 5xx
+// - Never sends messages of unknown errors to clients, unless they were added as gin.ErrorTypePublic
+//
+// Only the first error added with c.Error is handled; WithErrorAggregation handles all of them.
 //
-// For more advanced error handling (e.g., database-specific errors), implement a custom error handler in your application
-func ErrorHandler(logger Logger) gin.HandlerFunc {
+// Responses use the ApiError format by default; WithProblemDetails switches to RFC 9457 problem details.
+//
+// Application errors (e.g., sql.ErrNoRows, driver constraint violations) are mapped to responses
+// with WithErrorMapper, consulted before the built-in handling.
+func ErrorHandler(logger Logger, opts ...ErrorHandlerOption) gin.HandlerFunc {
+	cfg := &errorHandlerConfig{logger: logger}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	return func(c *gin.Context) {
+		// Expose the logger to route middleware such as WithTimeout
+		if logger != nil {
+			c.Set(LoggerKey, logger)
+		}
+		// Render and WithTimeout negotiate with the same formats as the error responses
+		if cfg.renderers != nil {
+			c.Set(RenderersKey, cfg.renderers)
+		}
+		// WithTimeout answers timeouts itself, in the error format of this handler
+		c.Set(errorHandlerKey, cfg)
+
 		// Setup panic recovery
 		defer func() {
 			if err := recover(); err != nil {
-				handlePanic(c, err, logger)
+				handlePanic(c, err, cfg)
 			}
 		}()
 
@@ -70,14 +134,73 @@ func ErrorHandler(logger Logger) gin.HandlerFunc {
 		c.Next()
 
 		// Handle any errors that were added during request processing
-		// Only handle the first error to avoid multiple responses
-		if len(c.Errors) > 0 {
-			handleBasicError(c, c.Errors[0].Err, logger)
+		// Only the first error is handled unless WithErrorAggregation is set
+		recorded := false
+		switch {
+		case len(c.Errors) == 0:
+		case cfg.aggregateErrors:
+			recorded = handleErrors(c, c.Errors, cfg)
+		default:
+			recorded = handleGinError(c, c.Errors[0], cfg)
+			for _, ginErr := range c.Errors[1:] {
+				resolveError(c, ginErr.Err, false, cfg).record(c, nil)
+			}
+		}
+
+		// TelemetryErrorFilter hides the errors recorded on the span from Telemetry, which would
+		// record them again and mark client errors (4xx) as span failures
+		if recorded {
+			c.Set(recordedErrorsKey, true)
 		}
 	}
 }
 
-// buildLogFields creates base log fields with request context and trace ID
+// errorHandlerKey is the context key of the ErrorHandler settings
+const errorHandlerKey = "error_handler"
+
+// ErrorHandlerOption configures ErrorHandler
+type ErrorHandlerOption func(*errorHandlerConfig)
+
+// errorHandlerConfig holds the ErrorHandler settings
+type errorHandlerConfig struct {
+	logger  Logger
+	mappers []ErrorMapper // Application error mappers, consulted first
+
+	problemDetails     bool   // Render RFC 9457 problem details instead of ApiError
+	problemTypeBaseURI string // Prefix of problem type URIs, "about:blank" types when empty
+	aggregateErrors    bool   // Respond with every error of the request instead of the first one
+
+	renderers *RendererRegistry // Formats of the request, Renderers when nil
+}
+
+// WithProblemDetails renders errors as RFC 9457 problem details (application/problem+json)
+// Problem types are typeBaseURI followed by the error kind (e.g., "https://errors.example.com/not-found");
+// with an empty typeBaseURI every problem has type "about:blank".
+func WithProblemDetails(typeBaseURI string) ErrorHandlerOption {
+	return func(cfg *errorHandlerConfig) {
+		cfg.problemDetails = true
+		cfg.problemTypeBaseURI = typeBaseURI
+	}
+}
+
+// WithErrorAggregation makes ErrorHandler handle every error added with c.Error instead of the first one
+// Each error is logged with its own error_type. When several errors occurred, the response uses the
+// most severe one (5xx over 4xx, then the first added) and lists all of them in cause.
+func WithErrorAggregation() ErrorHandlerOption {
+	return func(cfg *errorHandlerConfig) {
+		cfg.aggregateErrors = true
+	}
+}
+
+// WithRenderers negotiates the formats of responses with registry instead of Renderers
+// ErrorHandler exposes the registry to Render and WithTimeout (see RequestRenderers).
+func WithRenderers(registry *RendererRegistry) ErrorHandlerOption {
+	return func(cfg *errorHandlerConfig) {
+		cfg.renderers = registry
+	}
+}
+
+// buildLogFields creates base log fields with request context and trace IDs
 func buildLogFields(ctx *gin.Context) Fields {
 	logFields := Fields{
 		"client_ip": ctx.ClientIP(),
@@ -85,168 +208,322 @@ func buildLogFields(ctx *gin.Context) Fields {
 		"path":      ctx.Request.URL.Path,
 	}
 
-	// Add trace ID if available
-	if traceID := GetTraceID(ctx); traceID != "" {
-		logFields["trace_id"] = traceID
+	// Add trace and span IDs if available (W3C and Datadog formats)
+	addTraceLogFields(ctx, logFields)
+
+	// Add route tags if the route was registered with options
+	if rc := GetRouteConfig(ctx); rc != nil && len(rc.Tags) > 0 {
+		logFields["route_tags"] = rc.Tags
 	}
 
 	return logFields
 }
 
 // handlePanic handles panics and converts them to appropriate error responses
-func handlePanic(ctx *gin.Context, err any, logger Logger) {
+func handlePanic(ctx *gin.Context, err any, cfg *errorHandlerConfig) {
 	// Build log fields with request context
 	logFields := buildLogFields(ctx)
 
+	// Panics re-raised by WithTimeout carry the stack of the handler goroutine
+	stack := debug.Stack()
+	if hp, ok := err.(*handlerPanic); ok {
+		err = hp.value
+		stack = hp.stack
+	}
+
 	reqCtx := ctx.Request.Context()
 	switch er := err.(type) {
 	case error:
 		logFields["panic"] = er.Error()
-		logFields["stack_trace"] = string(debug.Stack())
-		logger.Error(reqCtx, "Panic recovered", logFields)
-		handleBasicError(ctx, er, logger)
+		logFields["stack_trace"] = string(stack)
+		cfg.logger.Error(reqCtx, "Panic recovered", logFields)
+		handleBasicError(ctx, er, stack, cfg)
 	default:
 		logFields["panic"] = fmt.Sprintf("%v", err)
-		logFields["stack_trace"] = string(debug.Stack())
-		logger.Error(reqCtx, "Panic recovered (non-error type)", logFields)
-		// Set Content-Type header before sending response
-		ctx.Header("Content-Type", "application/json; charset=utf-8")
-		ctx.AbortWithStatusJSON(
-			http.StatusInternalServerError,
-			NewApiError("Internal server error panic", http.StatusInternalServerError))
+		logFields["stack_trace"] = string(stack)
+		cfg.logger.Error(reqCtx, "Panic recovered (non-error type)", logFields)
+		recordSpanException(ctx, err, fmt.Sprintf("%v", err), "Panic", http.StatusInternalServerError, stack)
+		writeApiError(ctx, NewApiError("Internal server error panic", http.StatusInternalServerError), "Panic", cfg)
 	}
 }
 
-// handleBasicError handles different types of errors and converts them to appropriate HTTP responses
-// This version only handles platform-specific errors, not database-specific errors
-func handleBasicError(ctx *gin.Context, err error, logger Logger) {
-	var apiErr *ApiError
-	var errorType string
+// handledError is an error converted to its response
+type handledError struct {
+	err       error
+	apiErr    *ApiError
+	errorType string
+	logLevel  LogLevel
+	logFields Fields
+}
 
-	// Build log fields with request context
-	logFields := buildLogFields(ctx)
-	logFields["error"] = err.Error()
+// handleBasicError handles a recovered panic error and converts it to the appropriate HTTP response
+func handleBasicError(ctx *gin.Context, err error, stack []byte, cfg *errorHandlerConfig) {
+	handled := resolveError(ctx, err, false, cfg)
+	handled.log(ctx, cfg)
+	handled.record(ctx, stack)
+	writeApiError(ctx, handled.apiErr, handled.errorType, cfg)
+}
 
-	switch e := err.(type) {
-	case *platformErrors.NotFoundError:
-		errorType = "NotFoundError"
-		apiErr = NewApiError(e.Error(), http.StatusNotFound)
+// handleGinError handles an error added with c.Error
+// Messages of unknown errors are only sent to clients for public errors (gin.ErrorTypePublic).
+// Reports whether the error was recorded on the span.
+func handleGinError(ctx *gin.Context, ginErr *gin.Error, cfg *errorHandlerConfig) bool {
+	handled := resolveError(ctx, ginErr.Err, ginErr.IsType(gin.ErrorTypePublic), cfg)
+	handled.log(ctx, cfg)
+	recorded := handled.record(ctx, nil)
+	writeApiError(ctx, handled.apiErr, handled.errorType, cfg)
+	return recorded
+}
 
-	case *platformErrors.UnauthorizedError:
-		errorType = "UnauthorizedError"
-		apiErr = NewApiError(e.Error(), http.StatusUnauthorized)
+// handleErrors handles every error added with c.Error (WithErrorAggregation)
+// Reports whether the errors were recorded on the span.
+func handleErrors(ctx *gin.Context, ginErrs []*gin.Error, cfg *errorHandlerConfig) bool {
+	if len(ginErrs) == 1 {
+		return handleGinError(ctx, ginErrs[0], cfg)
+	}
 
-	case *platformErrors.ConflictError:
-		errorType = "ConflictError"
-		apiErr = NewApiError(e.Error(), http.StatusConflict)
+	var primary *handledError
+	recorded := false
+	causes := make([]any, 0, len(ginErrs))
+	for _, ginErr := range ginErrs {
+		handled := resolveError(ctx, ginErr.Err, ginErr.IsType(gin.ErrorTypePublic), cfg)
+		handled.log(ctx, cfg)
+		recorded = handled.record(ctx, nil) || recorded
+
+		handled.apiErr.errorType = handled.errorType
+		causes = append(causes, handled.apiErr)
+		if primary == nil || errorSeverity(handled.apiErr.Status) > errorSeverity(primary.apiErr.Status) {
+			primary = handled
+		}
+	}
 
-	case *platformErrors.ExternalServiceError:
-		errorType = "ExternalServiceError"
-		apiErr = NewApiError(e.Error(), e.Status())
-		logFields["external_status"] = e.Status()
+	apiErr := NewApiError(primary.apiErr.Message, primary.apiErr.Status, causes...)
+	apiErr.Code = primary.apiErr.Code
+	apiErr.Details = primary.apiErr.Details
+	apiErr.headers = primary.apiErr.headers
+	writeApiError(ctx, apiErr, primary.errorType, cfg)
+	return recorded
+}
 
-	case *platformErrors.BadRequestError:
-		errorType = "BadRequestError"
-		apiErr = NewApiError(e.Error(), http.StatusBadRequest)
+// errorSeverity ranks statuses by class: 5xx above 4xx above the others
+func errorSeverity(status int) int {
+	return status / 100
+}
 
-	case *platformErrors.ForbiddenError:
-		errorType = "ForbiddenError"
-		apiErr = NewApiError(e.Error(), http.StatusForbidden)
+// log logs the error with its severity
+func (h *handledError) log(ctx *gin.Context, cfg *errorHandlerConfig) {
+	cfg.logError(ctx.Request.Context(), h.logLevel, h.apiErr.Status, h.logFields)
+}
 
-	case *platformErrors.UnprocessableEntityError:
-		errorType = "UnprocessableEntityError"
-		apiErr = NewApiError(e.Error(), http.StatusUnprocessableEntity)
+// record records the error as an exception event of the active span
+func (h *handledError) record(ctx *gin.Context, stack []byte) bool {
+	return recordSpanException(ctx, h.err, h.err.Error(), h.errorType, h.apiErr.Status, stack)
+}
 
-	case *platformErrors.TooManyRequestsError:
-		errorType = "TooManyRequestsError"
-		apiErr = NewApiError(e.Error(), http.StatusTooManyRequests)
+// resolveError converts an error to its response and log fields
+// Registered mappers are tried first, then platform-specific errors; errors are matched anywhere
+// in their chain, so wrapped errors keep their status. The message of unknown errors is only
+// exposed when public is true.
+func resolveError(ctx *gin.Context, err error, public bool, cfg *errorHandlerConfig) *handledError {
+	var apiErr *ApiError
+	var errorType string
 
-	case *platformErrors.InternalServerError:
-		errorType = "InternalServerError"
-		apiErr = NewApiError(e.Error(), http.StatusInternalServerError)
+	// Build log fields with request context
+	logFields := buildLogFields(ctx)
+	logFields["error"] = err.Error()
 
-	case *platformErrors.ServiceUnavailableError:
-		errorType = "ServiceUnavailableError"
-		apiErr = NewApiError(e.Error(), http.StatusServiceUnavailable)
+	var httpErr platformErrors.HTTPError
+	var unmarshalTypeErr *json.UnmarshalTypeError
+	var validationErrs validator.ValidationErrors
+	var openAPIErr *OpenAPIValidationError
+	var syntaxErr *json.SyntaxError
+	var maxBytesErr *http.MaxBytesError
+	logLevel := LogLevelDefault
+
+	mapping, mapped := cfg.mapError(err)
+
+	// errors.As finds errors wrapped with fmt.Errorf("...: %w", err) as well
+	switch {
+	case mapped:
+		errorType = mapping.Type
+		apiErr = NewApiError(mapping.Message, mapping.Status, mapping.Cause...)
+		apiErr.Code = mapping.Code
+		apiErr.Details = mapping.Details
+		apiErr.headers = mapping.Headers
+		logLevel = mapping.LogLevel
+		if apiErr.Code != "" {
+			logFields["error_code"] = apiErr.Code
+		}
 
-	case *json.UnmarshalTypeError:
+	case errors.As(err, &httpErr):
+		errorType = errorTypeName(httpErr)
+		apiErr = NewApiError(httpErr.PublicMessage(), httpErr.Status())
+		apiErr.Code = httpErr.Code()
+		apiErr.Details = httpErr.Details()
+		apiErr.headers = httpErr.Headers()
+		if apiErr.Code != "" {
+			logFields["error_code"] = apiErr.Code
+		}
+		if len(apiErr.Details) > 0 {
+			logFields["error_details"] = apiErr.Details
+		}
+		if cause := errors.Unwrap(httpErr); cause != nil {
+			logFields["cause"] = cause.Error()
+		}
+		if _, ok := httpErr.(*platformErrors.ExternalServiceError); ok {
+			logFields["external_status"] = httpErr.Status()
+		}
+
+	case errors.As(err, &unmarshalTypeErr):
 		errorType = "UnmarshalTypeError"
 		apiErr = NewApiError(
 			fmt.Sprintf("Invalid type for field '%s', expected %s but got %s",
-				e.Field, e.Type.String(), e.Value),
+				unmarshalTypeErr.Field, unmarshalTypeErr.Type.String(), unmarshalTypeErr.Value),
 			http.StatusBadRequest,
 		)
-		logFields["field"] = e.Field
-		logFields["expected_type"] = e.Type.String()
+		logFields["field"] = unmarshalTypeErr.Field
+		logFields["expected_type"] = unmarshalTypeErr.Type.String()
 
-	case validator.ValidationErrors:
+	case errors.As(err, &validationErrs):
 		errorType = "ValidationError"
-		validationErrs := descriptiveValidationErrors(e)
-		apiErr = NewApiError("Validation error", http.StatusBadRequest, validationErrs)
-		logFields["validation_errors"] = validationErrs
+		fields := descriptiveValidationErrors(ctx, validationErrs)
+		apiErr = NewApiError("Validation error", http.StatusBadRequest, validationCauses(fields)...)
+		logFields["validation_errors"] = fields
+
+	case errors.As(err, &openAPIErr):
+		fields := translateViolations(ctx, openAPIErr.fields)
+		if openAPIErr.response {
+			errorType = "ResponseValidationError"
+			apiErr = NewApiError("Response does not match the API specification", http.StatusInternalServerError, validationCauses(fields)...)
+		} else {
+			errorType = "ValidationError"
+			apiErr = NewApiError("Validation error", http.StatusBadRequest, validationCauses(fields)...)
+		}
+		logFields["validation_errors"] = fields
 
-	case *json.SyntaxError:
+	case errors.As(err, &syntaxErr):
 		errorType = "JSONSyntaxError"
 		apiErr = NewApiError(
-			fmt.Sprintf("Invalid JSON syntax at position %d", e.Offset),
+			fmt.Sprintf("Invalid JSON syntax at position %d", syntaxErr.Offset),
 			http.StatusBadRequest,
 		)
-		logFields["offset"] = e.Offset
-		logFields["syntax_error"] = e.Error()
+		logFields["offset"] = syntaxErr.Offset
+		logFields["syntax_error"] = syntaxErr.Error()
+
+	case errors.As(err, &maxBytesErr):
+		errorType = "RequestBodyTooLarge"
+		apiErr = NewApiError(
+			fmt.Sprintf("Request body exceeds the limit of %d bytes", maxBytesErr.Limit),
+			http.StatusRequestEntityTooLarge,
+		)
+		logFields["limit_bytes"] = maxBytesErr.Limit
+
+	case errors.Is(err, io.EOF):
+		errorType = "EmptyBody"
+		apiErr = NewApiError("Request body is empty", http.StatusBadRequest)
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		errorType = "IncompleteBody"
+		apiErr = NewApiError("Request body is incomplete", http.StatusBadRequest)
+
+	case errors.Is(err, context.Canceled):
+		errorType = "RequestCanceled"
+		// 499 is nginx's non-standard status code for "Client Closed Request"
+		// Since HTTP doesn't have a standard code, we use 499 or could use 408 Request Timeout
+		apiErr = NewApiError("Request was cancelled by client", 499)
+		logFields["reason"] = "context_canceled"
+
+	case errors.Is(err, context.DeadlineExceeded):
+		errorType = "RequestTimeout"
+		apiErr = NewApiError("Request timeout exceeded", http.StatusRequestTimeout)
+		logFields["reason"] = "deadline_exceeded"
 
 	default:
-		// Check for specific error types using errors.Is
-		if errors.Is(err, io.EOF) {
-			errorType = "EmptyBody"
-			apiErr = NewApiError("Request body is empty", http.StatusBadRequest)
-		} else if errors.Is(err, io.ErrUnexpectedEOF) {
-			errorType = "IncompleteBody"
-			apiErr = NewApiError("Request body is incomplete", http.StatusBadRequest)
-		} else if err == context.Canceled {
-			// Check for context cancellation errors
-			errorType = "RequestCanceled"
-			// 499 is nginx's non-standard status code for "Client Closed Request"
-			// Since HTTP doesn't have a standard code, we use 499 or could use 408 Request Timeout
-			apiErr = NewApiError("Request was cancelled by client", 499)
-			logFields["reason"] = "context_canceled"
-		} else if err == context.DeadlineExceeded {
-			errorType = "RequestTimeout"
-			apiErr = NewApiError("Request timeout exceeded", http.StatusRequestTimeout)
-			logFields["reason"] = "deadline_exceeded"
-		} else {
-			errorType = "UnknownError"
-			apiErr = NewApiError("An error occurred", http.StatusInternalServerError)
-			// Log full error for unknown errors
-			logFields["full_error"] = fmt.Sprintf("%+v", err)
+		errorType = "UnknownError"
+		message := "An error occurred"
+		if public {
+			// Public errors were added for clients (c.Error(err).SetType(gin.ErrorTypePublic))
+			message = err.Error()
 		}
+		apiErr = NewApiError(message, http.StatusInternalServerError)
+		// Log full error for unknown errors
+		logFields["full_error"] = fmt.Sprintf("%+v", err)
 	}
 
 	// Add error type and status to log
 	logFields["error_type"] = errorType
 	logFields["status"] = apiErr.Status
 
-	// Log based on severity
-	reqCtx := ctx.Request.Context()
-	if apiErr.Status >= 500 {
-		logger.Error(reqCtx, "Server error", logFields)
-	} else {
-		logger.Warn(reqCtx, "Client error", logFields)
+	return &handledError{err: err, apiErr: apiErr, errorType: errorType, logLevel: logLevel, logFields: logFields}
+}
+
+// errorTypeName returns the type name of an error without pointer indirection (e.g., "NotFoundError")
+// HTTPError may be implemented by pointer or value types; unnamed types fall back to their description.
+func errorTypeName(err error) string {
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
+	if name := t.Name(); name != "" {
+		return name
+	}
+	return t.String()
+}
 
-	// Set Content-Type header before sending response
-	ctx.Header("Content-Type", "application/json; charset=utf-8")
-	ctx.AbortWithStatusJSON(apiErr.Status, apiErr)
+// writeApiError sends the error response unless a response was already written
+// (e.g., WithTimeout already answered with 408), guaranteeing a single response per request
+// The error is rendered in the format negotiated from the Accept header; when no registered
+// format is acceptable, a 406 is sent in the default format instead.
+func writeApiError(ctx *gin.Context, apiErr *ApiError, errorType string, cfg *errorHandlerConfig) {
+	ctx.Abort()
+	if ctx.Writer.Written() {
+		return
+	}
+
+	if cfg.problemDetails {
+		writeProblem(ctx, apiErr, errorType, cfg.problemTypeBaseURI)
+		return
+	}
+
+	registry := RequestRenderers(ctx)
+	renderer, ok := registry.Negotiate(ctx.GetHeader("Accept"))
+	if !ok {
+		renderer = registry.Default()
+		apiErr = NewApiError(notAcceptableMessage(registry), http.StatusNotAcceptable)
+	}
+	writeErrorHeaders(ctx, apiErr)
+	ctx.Render(apiErr.Status, negotiatedRender{renderer: renderer, value: apiErr})
+}
+
+// writeErrorHeaders sets the response headers carried by the error (Retry-After, WWW-Authenticate, Allow, ...)
+func writeErrorHeaders(ctx *gin.Context, apiErr *ApiError) {
+	for key, values := range apiErr.headers {
+		ctx.Writer.Header().Del(key)
+		for _, value := range values {
+			ctx.Writer.Header().Add(key, value)
+		}
+	}
 }
 
 // descriptiveValidationErrors converts validator.ValidationErrors to a descriptive format
-func descriptiveValidationErrors(validationErrs validator.ValidationErrors) []*validationError {
+// When ConfigureValidation was called, each error carries a message in the request locale.
+func descriptiveValidationErrors(ctx *gin.Context, validationErrs validator.ValidationErrors) []*validationError {
+	trans := validationTranslatorFor(ctx)
+
 	var errs []*validationError
 	for _, fieldErr := range validationErrs {
 		tagName := fieldErr.ActualTag()
 		if fieldErr.Param() != "" {
 			tagName = fmt.Sprintf("%s=%s", tagName, fieldErr.Param())
 		}
-		errs = append(errs, newValidationError(fieldErr.Field(), tagName))
+		validationErr := newValidationError(fieldErr.Field(), tagName)
+		if trans != nil {
+			// Tags without a registered message translate to the raw validator error
+			if msg := fieldErr.Translate(trans); msg != fieldErr.Error() {
+				validationErr.Message = msg
+			}
+		}
+		errs = append(errs, validationErr)
 	}
 	return errs
 }
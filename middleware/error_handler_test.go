@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// teapotError implements HTTPError with value receivers
+type teapotError struct{}
+
+func (teapotError) Error() string           { return "teapot" }
+func (teapotError) Status() int             { return http.StatusTeapot }
+func (teapotError) Code() string            { return "TEAPOT" }
+func (teapotError) Details() map[string]any { return nil }
+func (teapotError) PublicMessage() string   { return "I'm a teapot" }
+func (teapotError) Headers() http.Header    { return nil }
+
+func newErrorEngine(logger Logger, handler gin.HandlerFunc, opts ...ErrorHandlerOption) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ErrorHandler(logger, opts...))
+	engine.GET("/", handler)
+	return engine
+}
+
+func TestErrorHandlerHTTPErrorTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+	}{
+		{"value receiver", teapotError{}, http.StatusTeapot, "teapotError"},
+		{"pointer to value receiver", &teapotError{}, http.StatusTeapot, "teapotError"},
+		{"platform error", platformErrors.NewNotFoundError("Order not found"), http.StatusNotFound, "NotFoundError"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &testLogger{}
+			engine := newErrorEngine(logger, func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body["message"] == "Internal server error" {
+				t.Errorf("error was not resolved: %s", rec.Body.String())
+			}
+
+			logger.mu.Lock()
+			defer logger.mu.Unlock()
+			if len(logger.entries) != 1 {
+				t.Fatalf("logged %d entries, want 1: %v", len(logger.entries), logger.entries)
+			}
+			if got := logger.entries[0].fields["error_type"]; got != tt.wantType {
+				t.Errorf("error_type = %v, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestErrorHandlerAggregationRecordsEveryError(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	var remaining int
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		ctx, span := tracer.Start(c.Request.Context(), "request")
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		remaining = len(c.Errors)
+		span.End()
+	})
+	engine.Use(ErrorHandler(&testLogger{}, WithErrorAggregation()))
+	engine.GET("/", func(c *gin.Context) {
+		_ = c.Error(platformErrors.NewBadRequestError("Invalid input"))
+		_ = c.Error(platformErrors.NewNotFoundError("Order not found"))
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(ended))
+	}
+	if events := ended[0].Events(); len(events) != 2 {
+		t.Errorf("recorded %d exception events, want 2", len(events))
+	}
+	if remaining != 2 {
+		t.Errorf("%d errors left on the context, want both kept", remaining)
+	}
+}
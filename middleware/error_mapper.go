@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// LogLevel selects the logger method used for an error
+type LogLevel int
+
+// Log levels of mapped errors
+const (
+	LogLevelDefault LogLevel = iota // Error for 5xx statuses, Warn otherwise
+	LogLevelDebug
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// ErrorMapping describes the response of an error handled by an ErrorMapper
+type ErrorMapping struct {
+	// Status is the HTTP status code (500 when zero)
+	Status int
+
+	// Message is the message sent to clients (the status text when empty)
+	Message string
+
+	// Code is an optional machine-readable error code
+	Code string
+
+	// Details is optional metadata exposed to clients
+	Details map[string]any
+
+	// Cause lists optional causes exposed to clients (e.g., the violated constraint)
+	Cause []any
+
+	// Headers are written onto the response (e.g., Retry-After)
+	Headers http.Header
+
+	// Type names the error in logs and problem types (derived from the status when empty,
+	// e.g., "NotFoundError" for 404)
+	Type string
+
+	// LogLevel selects how the error is logged (LogLevelDefault: Error for 5xx, Warn otherwise)
+	LogLevel LogLevel
+}
+
+// ErrorMapper converts application errors to responses
+// It returns false for errors it does not handle, so the next mapper (or the built-in
+// handling) is used.
+type ErrorMapper func(err error) (ErrorMapping, bool)
+
+// WithErrorMapper registers a mapper consulted before the built-in error handling
+// Mappers are tried in registration order; the first one handling the error wins.
+//
+// Example:
+//
+//	httpplatform.ErrorHandler(logger,
+//	    httpplatform.WithErrorMapper(httpplatform.MapError(sql.ErrNoRows, httpplatform.ErrorMapping{
+//	        Status: http.StatusNotFound, Message: "Resource not found", LogLevel: httpplatform.LogLevelInfo,
+//	    })),
+//	)
+func WithErrorMapper(mapper ErrorMapper) ErrorHandlerOption {
+	return func(cfg *errorHandlerConfig) {
+		cfg.mappers = append(cfg.mappers, mapper)
+	}
+}
+
+// MapError creates a mapper for errors matching target with errors.Is (e.g., sql.ErrNoRows)
+func MapError(target error, mapping ErrorMapping) ErrorMapper {
+	return func(err error) (ErrorMapping, bool) {
+		if errors.Is(err, target) {
+			return mapping, true
+		}
+		return ErrorMapping{}, false
+	}
+}
+
+// MapErrorAs creates a mapper for errors of type T found with errors.As (e.g., *pq.Error)
+// fn builds the mapping from the matched error and may reject it by returning false.
+func MapErrorAs[T error](fn func(err T) (ErrorMapping, bool)) ErrorMapper {
+	return func(err error) (ErrorMapping, bool) {
+		var target T
+		if errors.As(err, &target) {
+			return fn(target)
+		}
+		return ErrorMapping{}, false
+	}
+}
+
+// mapError runs the registered mappers and fills in the mapping defaults
+func (cfg *errorHandlerConfig) mapError(err error) (ErrorMapping, bool) {
+	for _, mapper := range cfg.mappers {
+		mapping, ok := mapper(err)
+		if !ok {
+			continue
+		}
+
+		if mapping.Status == 0 {
+			mapping.Status = http.StatusInternalServerError
+		}
+		if mapping.Message == "" {
+			mapping.Message = http.StatusText(mapping.Status)
+		}
+		if mapping.Type == "" {
+			mapping.Type = strings.ReplaceAll(http.StatusText(mapping.Status), " ", "") + "Error"
+		}
+		return mapping, true
+	}
+	return ErrorMapping{}, false
+}
+
+// logError logs a handled error with the given level
+func (cfg *errorHandlerConfig) logError(reqCtx context.Context, level LogLevel, status int, fields Fields) {
+	msg := "Client error"
+	if status >= 500 {
+		msg = "Server error"
+	}
+
+	switch level {
+	case LogLevelDebug:
+		cfg.logger.Debug(reqCtx, msg, fields)
+	case LogLevelInfo:
+		cfg.logger.Info(reqCtx, msg, fields)
+	case LogLevelWarn:
+		cfg.logger.Warn(reqCtx, msg, fields)
+	case LogLevelError:
+		cfg.logger.Error(reqCtx, msg, fields)
+	default:
+		if status >= 500 {
+			cfg.logger.Error(reqCtx, msg, fields)
+		} else {
+			cfg.logger.Warn(reqCtx, msg, fields)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errNoRows = errors.New("no rows in result set")
+	errQuota  = errors.New("quota exceeded")
+)
+
+// constraintError stands in for a driver error matched with MapErrorAs
+type constraintError struct{ constraint string }
+
+func (e *constraintError) Error() string { return "violates " + e.constraint }
+
+func TestErrorMapperPrecedence(t *testing.T) {
+	mappers := []ErrorHandlerOption{
+		WithErrorMapper(MapErrorAs(func(err *constraintError) (ErrorMapping, bool) {
+			if err.constraint != "orders_email_key" {
+				return ErrorMapping{}, false
+			}
+			return ErrorMapping{Status: http.StatusConflict, Message: "Email already used", Cause: []any{err.constraint}}, true
+		})),
+		WithErrorMapper(MapError(errNoRows, ErrorMapping{Status: http.StatusNotFound, Message: "Order not found", LogLevel: LogLevelInfo})),
+		WithErrorMapper(MapError(errNoRows, ErrorMapping{Status: http.StatusGone})),
+		WithErrorMapper(MapErrorAs(func(*platformErrors.NotFoundError) (ErrorMapping, bool) {
+			return ErrorMapping{Status: http.StatusTeapot, Message: "I'm a teapot"}, true
+		})),
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+		wantLevel  string
+	}{
+		{"first matching mapper wins", fmt.Errorf("load order: %w", errNoRows), http.StatusNotFound, "Order not found", "info"},
+		{"MapErrorAs matches the type", fmt.Errorf("insert: %w", &constraintError{"orders_email_key"}), http.StatusConflict, "Email already used", "warn"},
+		{"rejected MapErrorAs falls through", &constraintError{"orders_pkey"}, http.StatusInternalServerError, "An error occurred", "error"},
+		{"mappers run before HTTPError", platformErrors.NewNotFoundError("Order not found"), http.StatusTeapot, "I'm a teapot", "warn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &testLogger{}
+			engine := newErrorEngine(logger, func(c *gin.Context) { _ = c.Error(tt.err) }, mappers...)
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			var body ApiError
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if rec.Code != tt.wantStatus || body.Message != tt.wantMsg {
+				t.Errorf("response = %d %q, want %d %q", rec.Code, body.Message, tt.wantStatus, tt.wantMsg)
+			}
+			logger.mu.Lock()
+			defer logger.mu.Unlock()
+			if len(logger.entries) != 1 || logger.entries[0].level != tt.wantLevel {
+				t.Errorf("log entries = %v, want one at %s", logger.entries, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestErrorMapperDefaults(t *testing.T) {
+	cfg := &errorHandlerConfig{}
+	WithErrorMapper(MapError(errNoRows, ErrorMapping{}))(cfg)
+	WithErrorMapper(MapError(errQuota, ErrorMapping{Status: http.StatusTooManyRequests, Type: "QuotaError"}))(cfg)
+
+	tests := []struct {
+		err                error
+		status             int
+		message, errorType string
+	}{
+		{errNoRows, http.StatusInternalServerError, "Internal Server Error", "InternalServerErrorError"},
+		{errQuota, http.StatusTooManyRequests, "Too Many Requests", "QuotaError"},
+	}
+	for _, tt := range tests {
+		mapping, ok := cfg.mapError(tt.err)
+		if !ok {
+			t.Fatalf("mapError(%v) not handled", tt.err)
+		}
+		if mapping.Status != tt.status || mapping.Message != tt.message || mapping.Type != tt.errorType {
+			t.Errorf("mapError(%v) = %d %q %q, want %d %q %q", tt.err,
+				mapping.Status, mapping.Message, mapping.Type, tt.status, tt.message, tt.errorType)
+		}
+	}
+	if _, ok := cfg.mapError(errors.New("unrelated")); ok {
+		t.Error("mapError handled an error no mapper matches")
+	}
+}
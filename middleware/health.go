@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthStatus is the status of a health check
+type HealthStatus string
+
+// Health statuses, from best to worst
+const (
+	HealthUp       HealthStatus = "up"       // Working normally
+	HealthDegraded HealthStatus = "degraded" // Working with reduced functionality (e.g., telemetry export failing)
+	HealthDown     HealthStatus = "down"     // Not working; the service should not receive traffic
+)
+
+// healthTimeout bounds the duration of a health report
+const healthTimeout = 5 * time.Second
+
+// HealthResult is the result of a health check
+type HealthResult struct {
+	Status  HealthStatus   `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// HealthCheck checks a dependency or subsystem
+// It should return quickly and honor ctx cancellation.
+type HealthCheck func(ctx context.Context) HealthResult
+
+// HealthReport is the aggregated result of every health check
+type HealthReport struct {
+	Status HealthStatus            `json:"status"`
+	Checks map[string]HealthResult `json:"checks,omitempty"`
+}
+
+// Health is a registry of health checks, reported by Endpoint
+type Health struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]HealthCheck
+}
+
+// NewHealth creates an empty health registry
+func NewHealth() *Health {
+	return &Health{checks: make(map[string]HealthCheck)}
+}
+
+// Register adds a health check, replacing any check with the same name
+func (h *Health) Register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, exists := h.checks[name]; !exists {
+		h.names = append(h.names, name)
+	}
+	h.checks[name] = check
+}
+
+// Check runs every health check concurrently
+// The report status is the worst status of the checks (up when there are none).
+func (h *Health) Check(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	names := append([]string(nil), h.names...)
+	checks := make([]HealthCheck, len(names))
+	for i, name := range names {
+		checks[i] = h.checks[name]
+	}
+	h.mu.RUnlock()
+
+	results := make([]HealthResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runHealthCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := HealthReport{Status: HealthUp}
+	if len(names) > 0 {
+		report.Checks = make(map[string]HealthResult, len(names))
+	}
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		report.Status = worseHealth(report.Status, results[i].Status)
+	}
+	return report
+}
+
+// runHealthCheck runs a check, reporting panics as down
+func runHealthCheck(ctx context.Context, check HealthCheck) (result HealthResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = HealthResult{Status: HealthDown, Error: "health check panicked"}
+		}
+	}()
+	return check(ctx)
+}
+
+// worseHealth returns the worse of two statuses (unknown statuses count as down)
+func worseHealth(a, b HealthStatus) HealthStatus {
+	rank := func(s HealthStatus) int {
+		switch s {
+		case HealthUp:
+			return 0
+		case HealthDegraded:
+			return 1
+		default:
+			return 2
+		}
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+// Endpoint returns the handler reporting the health checks as JSON
+// It answers 200 when the status is up or degraded and 503 when it is down.
+func (h *Health) Endpoint() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), healthTimeout)
+		defer cancel()
+
+		report := h.Check(ctx)
+		status := http.StatusOK
+		if report.Status == HealthDown {
+			status = http.StatusServiceUnavailable
+		}
+		c.Header("Cache-Control", "no-store")
+		c.JSON(status, report)
+	}
+}
@@ -47,8 +47,23 @@ type Logger interface {
 	Close() error
 }
 
+// LoggerKey is the gin context key of the platform logger, set by ErrorHandler
+const LoggerKey = "logger"
+
+// GetLogger returns the platform logger of the request, or nil outside ErrorHandler
+// Middleware mounted on routes (such as WithTimeout) uses it to log without taking a logger.
+func GetLogger(c *gin.Context) Logger {
+	if logger, exists := c.Get(LoggerKey); exists {
+		if l, ok := logger.(Logger); ok {
+			return l
+		}
+	}
+	return nil
+}
+
 // BasicLogger creates a request logger middleware using the platform logger interface
 // This middleware logs all incoming HTTP requests with method, path, status, and duration
+// Routes registered with Route.NoLog() are only logged when they complete with an error status.
 func BasicLogger(logger Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Start timer
@@ -77,18 +92,26 @@ func BasicLogger(logger Logger) gin.HandlerFunc {
 			fields["query"] = raw
 		}
 
-		// Add trace ID if available
-		if traceID := GetTraceID(c); traceID != "" {
-			fields["trace_id"] = traceID
-		}
+		// Add trace and span IDs if available (W3C and Datadog formats)
+		addTraceLogFields(c, fields)
 
 		// Add error if present
 		if len(c.Errors) > 0 {
-			fields["errors"] = c.Errors.String()
+			fields["errors"] = c.Errors.Errors()
 		}
 
 		// Log based on status code
 		status := c.Writer.Status()
+		rc := GetRouteConfig(c)
+		if rc != nil {
+			if rc.NoLog && status < 400 {
+				return
+			}
+			if len(rc.Tags) > 0 {
+				fields["route_tags"] = rc.Tags
+			}
+		}
+
 		ctx := c.Request.Context()
 		if status >= 500 {
 			logger.Error(ctx, "Request completed with server error", fields)
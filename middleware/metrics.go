@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute is the route label of requests matching no route, so unknown paths
+// (e.g., scanners) cannot create new series
+const unmatchedRoute = "unmatched"
+
+// MetricsConfig configures the Prometheus metrics
+type MetricsConfig struct {
+	// Namespace prefixes metric names (e.g., "orders" gives orders_http_requests_total)
+	Namespace string
+
+	// DurationBuckets are the latency histogram buckets in seconds (default: prometheus.DefBuckets)
+	DurationBuckets []float64
+
+	// SizeBuckets are the request/response size histogram buckets in bytes (default: 100B to 10MB)
+	SizeBuckets []float64
+}
+
+// Metrics records RED metrics (rate, errors, duration) of HTTP requests in a Prometheus registry
+// Series are labeled by method, route template (c.FullPath()) and status class ("2xx", "4xx", ...),
+// never by raw path or exact status, which keeps label cardinality bounded by the registered routes.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	inFlight     *prometheus.GaugeVec
+	requestSize  *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+}
+
+// NewMetrics creates the HTTP metrics in a new registry, together with Go runtime and process metrics
+func NewMetrics(cfg MetricsConfig) *Metrics {
+	if len(cfg.DurationBuckets) == 0 {
+		cfg.DurationBuckets = prometheus.DefBuckets
+	}
+	if len(cfg.SizeBuckets) == 0 {
+		cfg.SizeBuckets = prometheus.ExponentialBuckets(100, 10, 6) // 100B to 10MB
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of HTTP requests.",
+		}, []string{"method", "route", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests in seconds.",
+			Buckets:   cfg.DurationBuckets,
+		}, []string{"method", "route", "status_class"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Number of HTTP requests being served.",
+		}, []string{"method", "route"}),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_request_size_bytes",
+			Help:      "Size of HTTP request bodies in bytes.",
+			Buckets:   cfg.SizeBuckets,
+		}, []string{"method", "route"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.Namespace,
+			Name:      "http_response_size_bytes",
+			Help:      "Size of HTTP response bodies in bytes.",
+			Buckets:   cfg.SizeBuckets,
+		}, []string{"method", "route", "status_class"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests, m.duration, m.inFlight, m.requestSize, m.responseSize,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Registry returns the registry of the metrics, to register application metrics
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler returns the middleware recording the metrics of each request
+// It must run before ErrorHandler so the status of error responses is recorded.
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		method := metricMethod(c.Request.Method)
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		inFlight := m.inFlight.WithLabelValues(method, route)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		c.Next()
+
+		statusClass := strconv.Itoa(c.Writer.Status()/100) + "xx"
+		m.requests.WithLabelValues(method, route, statusClass).Inc()
+		m.duration.WithLabelValues(method, route, statusClass).Observe(time.Since(start).Seconds())
+		m.requestSize.WithLabelValues(method, route).Observe(float64(max(c.Request.ContentLength, 0)))
+		m.responseSize.WithLabelValues(method, route, statusClass).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
+
+// Endpoint returns the handler exposing the metrics in the Prometheus text format,
+// or OpenMetrics when requested through the Accept header
+func (m *Metrics) Endpoint() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	}))
+}
+
+// metricMethod keeps the standard methods and groups the others, since the method is client controlled
+func metricMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
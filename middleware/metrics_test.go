@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsLabels(t *testing.T) {
+	metrics := NewMetrics(MetricsConfig{Namespace: "orders"})
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(metrics.Handler())
+	engine.GET("/orders/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.Handle("PURGE", "/orders/:id", func(c *gin.Context) { c.Status(http.StatusAccepted) })
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/orders/1"},
+		{http.MethodGet, "/orders/2"},
+		{"PURGE", "/orders/3"},
+		{http.MethodGet, "/wp-admin/setup.php"},
+		{http.MethodGet, "/.env"},
+	} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	tests := []struct {
+		method, route, statusClass string
+		want                       float64
+	}{
+		{http.MethodGet, "/orders/:id", "2xx", 2},
+		{"OTHER", "/orders/:id", "2xx", 1},
+		{http.MethodGet, unmatchedRoute, "4xx", 2},
+	}
+	for _, tt := range tests {
+		got := testutil.ToFloat64(metrics.requests.WithLabelValues(tt.method, tt.route, tt.statusClass))
+		if got != tt.want {
+			t.Errorf("requests{%s %s %s} = %v, want %v", tt.method, tt.route, tt.statusClass, got, tt.want)
+		}
+	}
+	if series := testutil.CollectAndCount(metrics.requests); series != len(tests) {
+		t.Errorf("requests has %d series, want %d", series, len(tests))
+	}
+}
@@ -0,0 +1,692 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// OpenAPIValidationConfig configures the OpenAPIValidation middleware
+type OpenAPIValidationConfig struct {
+	// BasePath is the prefix of the gin routes that is not part of the document paths
+	// (e.g., "/api/v1" when the document declares "/users/{id}" and the route is "/api/v1/users/:id")
+	BasePath string
+
+	// RejectUnknownRoutes rejects requests to routes the document does not declare with 404
+	// By default they are passed through without validation.
+	RejectUnknownRoutes bool
+
+	// ValidateResponses validates the responses written by handlers as well (intended for tests)
+	// Responses are buffered; a mismatch replaces the response with a 500 listing the differences.
+	ValidateResponses bool
+}
+
+// OpenAPIValidationError is reported when a request or a response does not match the OpenAPI document
+// ErrorHandler converts it to 400 (request) or 500 (response) with the mismatches listed in cause,
+// using the same {field, reason, message} format as binding validation errors.
+type OpenAPIValidationError struct {
+	response bool
+	fields   []*validationError
+}
+
+func (e *OpenAPIValidationError) Error() string {
+	parts := make([]string, 0, len(e.fields))
+	for _, f := range e.fields {
+		parts = append(parts, f.Field+": "+f.Reason)
+	}
+
+	subject := "request"
+	if e.response {
+		subject = "response"
+	}
+	return fmt.Sprintf("%s does not match the API specification: %s", subject, strings.Join(parts, "; "))
+}
+
+// IsResponse reports whether the error describes the response rather than the request
+func (e *OpenAPIValidationError) IsResponse() bool {
+	return e.response
+}
+
+// LoadOpenAPISpec loads and validates an OpenAPI 3.0/3.1 document (JSON or YAML) from a file
+func LoadOpenAPISpec(path string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document %s: %w", path, err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// specOperation is an operation of the document indexed by route shape
+type specOperation struct {
+	path     string
+	item     *openapi3.PathItem
+	method   string
+	op       *openapi3.Operation
+	segments map[int]string // Index of each path parameter segment -> parameter name in the document
+}
+
+// OpenAPIValidation creates a middleware that validates requests against an OpenAPI document
+// Path parameters, query parameters, headers and request bodies are validated before the handler runs.
+// Invalid requests are aborted with an *OpenAPIValidationError, which ErrorHandler reports as:
+//
+//	{"message": "Validation error", "status": 400, "cause": [{"field": "quantity", "reason": "min=1", "message": "quantity must be 1 or greater"}]}
+//
+// Schema keywords are reported as the validator tag with the same meaning (minLength=3 as min=3,
+// enum as oneof, format=email as email) and translated like binding errors when ConfigureValidation
+// was called. Keywords without a validator tag (type, pattern, multipleOf) keep their name.
+//
+// Routes are matched through the gin route template (c.FullPath()), so parameter names may differ
+// between the routes and the document. Security requirements are not checked (authentication is
+// left to the application).
+//
+// Example:
+//
+//	spec, err := httpplatform.LoadOpenAPISpec("api/openapi.yaml")
+//	validation, err := httpplatform.OpenAPIValidation(spec, httpplatform.OpenAPIValidationConfig{BasePath: "/api/v1"})
+//	platform.Use(validation)
+func OpenAPIValidation(spec *openapi3.T, cfg OpenAPIValidationConfig) (gin.HandlerFunc, error) {
+	if spec == nil {
+		return nil, errors.New("openapi validation: spec cannot be nil")
+	}
+
+	operations := make(map[string]*specOperation)
+	for path, item := range spec.Paths.Map() {
+		segments := make(map[int]string)
+		for i, segment := range strings.Split(path, "/") {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				segments[i] = segment[1 : len(segment)-1]
+			}
+		}
+		for method, op := range item.Operations() {
+			operations[method+" "+specShape(path)] = &specOperation{
+				path:     path,
+				item:     item,
+				method:   method,
+				op:       op,
+				segments: segments,
+			}
+		}
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:            true,
+		SkipSettingDefaults:   true,
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		fullPath := c.FullPath()
+		if fullPath == "" {
+			// Unmatched routes are answered by gin (404/405)
+			c.Next()
+			return
+		}
+
+		routePath := fullPath
+		if cfg.BasePath != "" && strings.HasPrefix(routePath, cfg.BasePath) {
+			routePath = "/" + strings.TrimPrefix(strings.TrimPrefix(routePath, cfg.BasePath), "/")
+		}
+
+		operation, ok := operations[c.Request.Method+" "+specShape(routePath)]
+		if !ok {
+			if cfg.RejectUnknownRoutes {
+				c.Error(platformErrors.NewNotFoundError(fmt.Sprintf("%s %s is not declared in the API specification", c.Request.Method, fullPath)))
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: operation.pathParams(c, routePath),
+			Route: &routers.Route{
+				Spec:      spec,
+				Path:      operation.path,
+				PathItem:  operation.item,
+				Method:    operation.method,
+				Operation: operation.op,
+			},
+			Options: options,
+		}
+
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			c.Error(&OpenAPIValidationError{fields: requestValidationErrors(err)})
+			c.Abort()
+			return
+		}
+
+		if !cfg.ValidateResponses {
+			c.Next()
+			return
+		}
+
+		validateResponse(c, input, options)
+	}, nil
+}
+
+// validateResponse runs the remaining handlers with a buffered writer and validates their response
+// The buffered response is only sent if it matches the document.
+func validateResponse(c *gin.Context, input *openapi3filter.RequestValidationInput, options *openapi3filter.Options) {
+	original := c.Writer
+	buffer := newTimeoutWriter(original)
+	c.Writer = buffer
+	defer func() {
+		c.Writer = original
+	}()
+
+	c.Next()
+
+	// Errors are answered by ErrorHandler with the platform error shape
+	if !buffer.Written() && len(c.Errors) > 0 {
+		return
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 buffer.Status(),
+		Header:                 buffer.Header(),
+		Options:                options,
+	}
+	responseInput.SetBodyBytes(buffer.body.Bytes())
+
+	if err := openapi3filter.ValidateResponse(context.WithoutCancel(c.Request.Context()), responseInput); err != nil {
+		c.Error(&OpenAPIValidationError{response: true, fields: responseValidationErrors(err)})
+		c.Abort()
+		return
+	}
+
+	buffer.flushTo(original)
+}
+
+// pathParams maps the gin path parameters to the parameter names used by the document
+func (o *specOperation) pathParams(c *gin.Context, routePath string) map[string]string {
+	params := make(map[string]string, len(o.segments))
+	segments := strings.Split(routePath, "/")
+	for i, name := range o.segments {
+		if i >= len(segments) || len(segments[i]) < 2 {
+			continue
+		}
+		value := c.Param(segments[i][1:])
+		if segments[i][0] == '*' {
+			value = strings.TrimPrefix(value, "/")
+		}
+		params[name] = value
+	}
+	return params
+}
+
+// specShape normalizes gin ("/users/:id", "/files/*path") and OpenAPI ("/users/{id}") path templates
+// so that routes differing only by parameter syntax or names compare equal
+func specShape(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") ||
+			(strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")) {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// requestValidationErrors converts the errors returned by ValidateRequest to validation errors
+func requestValidationErrors(err error) []*validationError {
+	var errs []*validationError
+	for _, e := range flattenErrors(err) {
+		var reqErr *openapi3filter.RequestError
+		if !errors.As(e, &reqErr) {
+			errs = append(errs, newValidationError("request", e.Error()))
+			continue
+		}
+
+		switch {
+		case reqErr.Parameter != nil:
+			errs = append(errs, parameterErrors(reqErr)...)
+		case reqErr.RequestBody != nil:
+			errs = append(errs, bodyErrors("body", reqErr.Err, reqErr.Reason)...)
+		default:
+			errs = append(errs, newValidationError("request", reqErr.Error()))
+		}
+	}
+	return errs
+}
+
+// responseValidationErrors converts the errors returned by ValidateResponse to validation errors
+func responseValidationErrors(err error) []*validationError {
+	var errs []*validationError
+	for _, e := range flattenErrors(err) {
+		var respErr *openapi3filter.ResponseError
+		if errors.As(e, &respErr) {
+			errs = append(errs, bodyErrors("response", respErr.Err, respErr.Reason)...)
+			continue
+		}
+		errs = append(errs, newValidationError("response", e.Error()))
+	}
+	return errs
+}
+
+// parameterErrors converts a parameter error; the field is the parameter name
+func parameterErrors(reqErr *openapi3filter.RequestError) []*validationError {
+	name := reqErr.Parameter.Name
+
+	if errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) {
+		return []*validationError{newViolationError(name, "required")}
+	}
+
+	var errs []*validationError
+	for _, v := range schemaViolations(reqErr.Err) {
+		errs = append(errs, newViolationError(name, v.reason))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Values that could not be parsed (e.g., "abc" for an integer) report the expected type
+	if schema := reqErr.Parameter.Schema; schema != nil && schema.Value != nil && schema.Value.Type != nil {
+		return []*validationError{newValidationError(name, "type="+strings.Join(schema.Value.Type.Slice(), ","))}
+	}
+	return []*validationError{newValidationError(name, reqErr.Error())}
+}
+
+// bodyErrors converts body schema errors; the field is the dotted JSON path of the invalid value
+func bodyErrors(root string, err error, reason string) []*validationError {
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		return []*validationError{newViolationError(root, "required")}
+	}
+
+	var errs []*validationError
+	for _, v := range schemaViolations(err) {
+		field := root
+		if len(v.path) > 0 {
+			field = strings.Join(v.path, ".")
+		}
+		errs = append(errs, newViolationError(field, v.reason))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if reason == "" && err != nil {
+		reason = err.Error()
+	}
+	return []*validationError{newValidationError(root, reason)}
+}
+
+// flattenErrors expands openapi3.MultiError recursively
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	// Only expand the error itself: wrapped MultiErrors belong to the RequestError wrapping them
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var errs []error
+		for _, e := range multi {
+			errs = append(errs, flattenErrors(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// schemaViolation is a single schema keyword violated by the value at path
+type schemaViolation struct {
+	path   []string
+	reason string
+}
+
+// schemaViolations extracts the violated keywords from schema errors
+// OpenAPI 3.0 documents are validated by kin-openapi itself, which reports the failing schema;
+// OpenAPI 3.1 documents are validated as JSON Schema 2020-12, which only reports messages
+// (e.g., "at '/product': minLength: got 1, want 3") that are parsed back into keywords.
+func schemaViolations(err error) []schemaViolation {
+	var violations []schemaViolation
+	for _, e := range flattenErrors(err) {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			continue
+		}
+
+		if schemaErr.Schema != nil {
+			violations = append(violations, schemaViolation{path: schemaErr.JSONPointer(), reason: schemaReason(schemaErr)})
+			continue
+		}
+
+		var causes openapi3.MultiError
+		if schemaErr.Origin != nil && errors.As(schemaErr.Origin, &causes) {
+			violations = append(violations, schemaViolations(causes)...)
+			continue
+		}
+		violations = append(violations, jsonSchemaViolations(schemaErr.Reason)...)
+	}
+	return violations
+}
+
+var (
+	// jsonSchemaMessage matches a JSON Schema 2020-12 error: "at '<instance location>': <message>"
+	jsonSchemaMessage = regexp.MustCompile(`at '([^']*)': (.+)`)
+	// jsonSchemaBound matches bound violations, e.g. "minLength: got 1, want 3"
+	jsonSchemaBound = regexp.MustCompile(`^(\w+): got .+, want (.+)$`)
+	// jsonSchemaType matches type violations, e.g. "got string, want integer"
+	jsonSchemaType    = regexp.MustCompile(`^got .+, want (.+)$`)
+	jsonSchemaQuoted  = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'`)
+	jsonSchemaPattern = regexp.MustCompile(`does not match pattern '(.*)'$`)
+	jsonSchemaFormat  = regexp.MustCompile(`is not valid ([\w-]+)`)
+)
+
+// jsonSchemaViolations parses the message of a JSON Schema 2020-12 validation error
+func jsonSchemaViolations(reason string) []schemaViolation {
+	line, _, _ := strings.Cut(reason, "\n")
+	match := jsonSchemaMessage.FindStringSubmatch(line)
+	if match == nil {
+		return []schemaViolation{{reason: line}}
+	}
+
+	var path []string
+	if location := strings.Trim(match[1], "/"); location != "" {
+		path = strings.Split(location, "/")
+	}
+	message := match[2]
+
+	switch {
+	case strings.HasPrefix(message, "missing propert"):
+		// Report each missing property as its own required field
+		var violations []schemaViolation
+		for _, name := range jsonSchemaQuoted.FindAllStringSubmatch(message, -1) {
+			violations = append(violations, schemaViolation{path: append(slices.Clone(path), name[1]), reason: "required"})
+		}
+		return violations
+	case strings.HasPrefix(message, "value must be"):
+		values := jsonSchemaQuoted.FindAllStringSubmatch(message, -1)
+		enum := make([]string, 0, len(values))
+		for _, v := range values {
+			enum = append(enum, v[1])
+		}
+		return []schemaViolation{{path: path, reason: "enum=" + strings.Join(enum, " ")}}
+	}
+
+	if m := jsonSchemaBound.FindStringSubmatch(message); m != nil {
+		return []schemaViolation{{path: path, reason: m[1] + "=" + m[2]}}
+	}
+	if m := jsonSchemaType.FindStringSubmatch(message); m != nil {
+		return []schemaViolation{{path: path, reason: "type=" + strings.ReplaceAll(m[1], " or ", ",")}}
+	}
+	if m := jsonSchemaPattern.FindStringSubmatch(message); m != nil {
+		return []schemaViolation{{path: path, reason: "pattern=" + m[1]}}
+	}
+	if m := jsonSchemaFormat.FindStringSubmatch(message); m != nil {
+		return []schemaViolation{{path: path, reason: "format=" + m[1]}}
+	}
+	return []schemaViolation{{path: path, reason: message}}
+}
+
+// schemaReason formats the violated schema keyword like a validator tag (e.g., "minLength=3")
+func schemaReason(err *openapi3.SchemaError) string {
+	schema := err.Schema
+	switch err.SchemaField {
+	case "required":
+		return "required"
+	case "type":
+		return "type=" + strings.Join(schema.Type.Slice(), ",")
+	case "format":
+		return "format=" + schema.Format
+	case "pattern":
+		return "pattern=" + schema.Pattern
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		return "enum=" + strings.Join(values, " ")
+	case "minLength":
+		return "minLength=" + strconv.FormatUint(schema.MinLength, 10)
+	case "maxLength":
+		return keywordUint("maxLength", schema.MaxLength)
+	case "minItems":
+		return "minItems=" + strconv.FormatUint(schema.MinItems, 10)
+	case "maxItems":
+		return keywordUint("maxItems", schema.MaxItems)
+	case "minProperties":
+		return "minProperties=" + strconv.FormatUint(schema.MinProps, 10)
+	case "maxProperties":
+		return keywordUint("maxProperties", schema.MaxProps)
+	case "minimum":
+		return keywordFloat("minimum", schema.Min)
+	case "maximum":
+		return keywordFloat("maximum", schema.Max)
+	case "exclusiveMinimum":
+		if schema.ExclusiveMin.Value != nil {
+			return keywordFloat("exclusiveMinimum", schema.ExclusiveMin.Value)
+		}
+		return keywordFloat("exclusiveMinimum", schema.Min)
+	case "exclusiveMaximum":
+		if schema.ExclusiveMax.Value != nil {
+			return keywordFloat("exclusiveMaximum", schema.ExclusiveMax.Value)
+		}
+		return keywordFloat("exclusiveMaximum", schema.Max)
+	case "multipleOf":
+		return keywordFloat("multipleOf", schema.MultipleOf)
+	case "":
+		return err.Reason
+	default:
+		return err.SchemaField
+	}
+}
+
+// keywordUint formats an optional unsigned keyword value
+func keywordUint(keyword string, value *uint64) string {
+	if value == nil {
+		return keyword
+	}
+	return keyword + "=" + strconv.FormatUint(*value, 10)
+}
+
+// keywordFloat formats an optional numeric keyword value
+func keywordFloat(keyword string, value *float64) string {
+	if value == nil {
+		return keyword
+	}
+	return keyword + "=" + strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// keywordTag is the validator tag with the meaning of a schema keyword, and the kind of value it checks
+type keywordTag struct {
+	tag  string
+	kind reflect.Kind
+}
+
+// keywordTags maps schema keywords to validator tags, the inverse of the openapi package mapping
+var keywordTags = map[string]keywordTag{
+	"required":         {"required", reflect.String},
+	"minLength":        {"min", reflect.String},
+	"maxLength":        {"max", reflect.String},
+	"minItems":         {"min", reflect.Slice},
+	"maxItems":         {"max", reflect.Slice},
+	"minProperties":    {"min", reflect.Map},
+	"maxProperties":    {"max", reflect.Map},
+	"minimum":          {"min", reflect.Float64},
+	"maximum":          {"max", reflect.Float64},
+	"exclusiveMinimum": {"gt", reflect.Float64},
+	"exclusiveMaximum": {"lt", reflect.Float64},
+	"enum":             {"oneof", reflect.String},
+}
+
+// formatTags maps string formats to validator tags
+var formatTags = map[string]string{
+	"email":     "email",
+	"uuid":      "uuid",
+	"uri":       "uri",
+	"ipv4":      "ipv4",
+	"ipv6":      "ipv6",
+	"hostname":  "hostname",
+	"date-time": "datetime=" + time.RFC3339,
+	"date":      "datetime=" + time.DateOnly,
+}
+
+// newViolationError creates the validation error of a schema keyword violation (e.g., "minLength=3"),
+// reported as the matching validator tag (e.g., "min=3")
+func newViolationError(field, reason string) *validationError {
+	keyword, param, hasParam := strings.Cut(reason, "=")
+	if keyword == "format" {
+		if tag, ok := formatTags[param]; ok {
+			return &validationError{Field: field, Reason: tag, kind: reflect.String}
+		}
+		return newValidationError(field, reason)
+	}
+
+	mapping, ok := keywordTags[keyword]
+	if !ok || (!hasParam && keyword != "required") {
+		// Keywords without a validator tag, or bounds without a value, keep the keyword
+		return newValidationError(field, reason)
+	}
+	if hasParam {
+		reason = mapping.tag + "=" + param
+	} else {
+		reason = mapping.tag
+	}
+	return &validationError{Field: field, Reason: reason, kind: mapping.kind}
+}
+
+// translateViolations returns the violations with their message in the request locale
+// Messages come from validating a value that fails the same tag, so built-in translations,
+// Messages overrides and plural rules apply as for binding errors.
+func translateViolations(ctx *gin.Context, fields []*validationError) []*validationError {
+	messages := violationMessages.Load()
+	if messages == nil {
+		return fields
+	}
+	trans := requestTranslator(ctx, messages.uni)
+
+	translated := make([]*validationError, len(fields))
+	for i, field := range fields {
+		translated[i] = field
+		if field.kind == reflect.Invalid {
+			continue
+		}
+		if msg := violationMessage(messages.validate, trans, field); msg != "" {
+			withMessage := *field
+			withMessage.Message = msg
+			translated[i] = &withMessage
+		}
+	}
+	return translated
+}
+
+// violationField stands for the field name in the struct validated by violationMessage
+// Field names are JSON pointers chosen by clients (array indices, additional property keys):
+// putting them in struct tags would create a new type per request path, and reflect never
+// releases types.
+const violationField = "\x00field\x00"
+
+// violationTypes caches the struct validated per validate tag and value type, so their number
+// is bounded by the schema keywords
+var violationTypes sync.Map // violationTypeKey -> reflect.Type
+
+// violationTypeKey identifies a struct built by violationMessage
+type violationTypeKey struct {
+	validate  string
+	valueType reflect.Type
+}
+
+// violationMessage validates a value violating the tag of field and translates the resulting error
+func violationMessage(v *validator.Validate, trans ut.Translator, field *validationError) string {
+	tag, param, _ := strings.Cut(field.Reason, "=")
+	value, ok := failingValue(tag, param, field.kind)
+	if !ok {
+		return ""
+	}
+
+	// Commas and pipes separate tags, the validator reads them as hex escapes in parameters
+	escaped := field.Reason
+	if param != "" {
+		escaped = tag + "=" + strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(param)
+	}
+	key := violationTypeKey{validate: escaped, valueType: value.Type()}
+	structType, ok := violationTypes.Load(key)
+	if !ok {
+		structType, _ = violationTypes.LoadOrStore(key, reflect.StructOf([]reflect.StructField{{
+			Name: "Value",
+			Type: value.Type(),
+			Tag:  reflect.StructTag(fmt.Sprintf("name:%q validate:%q", violationField, escaped)),
+		}}))
+	}
+	target := reflect.New(structType.(reflect.Type)).Elem()
+	target.Field(0).Set(value)
+
+	var validationErrs validator.ValidationErrors
+	if err := v.Struct(target.Interface()); !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return ""
+	}
+	fieldErr := validationErrs[0]
+	// Tags without a registered message translate to the raw validator error
+	if msg := fieldErr.Translate(trans); msg != fieldErr.Error() {
+		return strings.ReplaceAll(msg, violationField, field.Field)
+	}
+	return ""
+}
+
+// failingValue returns a value of the given kind that does not satisfy tag=param
+func failingValue(tag, param string, kind reflect.Kind) (reflect.Value, bool) {
+	switch kind {
+	case reflect.Float64:
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		switch tag {
+		case "min":
+			bound--
+		case "max":
+			bound++
+		}
+		return reflect.ValueOf(bound), true
+	case reflect.Slice, reflect.Map:
+		size := 0
+		if tag == "max" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			size = n + 1
+		}
+		if kind == reflect.Map {
+			value := reflect.ValueOf(make(map[string]bool, size))
+			for i := range size {
+				value.SetMapIndex(reflect.ValueOf(strconv.Itoa(i)), reflect.ValueOf(true))
+			}
+			return value, true
+		}
+		return reflect.ValueOf(make([]bool, size)), true
+	default:
+		if tag == "max" {
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			return reflect.ValueOf(strings.Repeat("x", n+1)), true
+		}
+		// The empty string fails required, min, oneof and the formats
+		return reflect.ValueOf(""), true
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// orderSpec declares POST /orders with constraints on a query parameter and the body
+const orderSpec = `
+openapi: %s
+info: {title: orders, version: "1"}
+paths:
+  /orders:
+    post:
+      parameters:
+        - {name: page, in: query, schema: {type: integer, minimum: 1}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [product, email]
+              properties:
+                product: {type: string, minLength: 3}
+                email: {type: string, format: email}
+                channel: {type: string, enum: [web, mobile]}
+                tags: {type: array, maxItems: 2, items: {type: string}}
+      responses:
+        "201": {description: created}
+`
+
+func TestOpenAPIValidationReasonsMatchBinding(t *testing.T) {
+	if err := ConfigureValidation(ValidationConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]validationError{
+		"page":    {Field: "page", Reason: "min=1", Message: "page must be 1 or greater"},
+		"product": {Field: "product", Reason: "min=3", Message: "product must be at least 3 characters in length"},
+		"email":   {Field: "email", Reason: "required", Message: "email is a required field"},
+		"channel": {Field: "channel", Reason: "oneof=web mobile", Message: "channel must be one of [web mobile]"},
+		"tags":    {Field: "tags", Reason: "max=2", Message: "tags must contain at maximum 2 items"},
+	}
+
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			spec, err := openapi3.NewLoader().LoadFromData([]byte(strings.Replace(orderSpec, "%s", version, 1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			validation, err := OpenAPIValidation(spec, OpenAPIValidationConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.Use(ErrorHandler(&testLogger{}), validation)
+			engine.POST("/orders", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/orders?page=0",
+				strings.NewReader(`{"product": "ab", "channel": "fax", "tags": ["a", "b", "c"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+			var body struct {
+				Cause []validationError `json:"cause"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			got := make(map[string]validationError)
+			for _, cause := range body.Cause {
+				got[cause.Field] = cause
+			}
+			for field, w := range want {
+				if got[field] != w {
+					t.Errorf("%s: cause = %+v, want %+v", field, got[field], w)
+				}
+			}
+		})
+	}
+}
+
+func TestViolationMessagesShareTypesAcrossFields(t *testing.T) {
+	if err := ConfigureValidation(ValidationConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/orders", nil)
+
+	countTypes := func() int {
+		n := 0
+		violationTypes.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+
+	var fields []*validationError
+	for i := range 50 {
+		fields = append(fields, newViolationError("items/"+strconv.Itoa(i)+"/sku", "minLength=3"))
+	}
+	translateViolations(ctx, fields[:1])
+	before := countTypes()
+
+	translated := translateViolations(ctx, fields)
+	if after := countTypes(); after != before {
+		t.Errorf("%d struct types after 50 field paths, want %d", after, before)
+	}
+	if want := "items/49/sku must be at least 3 characters in length"; translated[49].Message != want {
+		t.Errorf("message = %q, want %q", translated[49].Message, want)
+	}
+}
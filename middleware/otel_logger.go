@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// OTelLoggerConfig configures an OTelLogger
+type OTelLoggerConfig struct {
+	// Name is the instrumentation scope of the log records (default: "http-platform")
+	Name string
+
+	// Provider emits the log records (default: the global logger provider, set by the
+	// platform when telemetry logs are enabled)
+	Provider log.LoggerProvider
+
+	// Tee also sends every entry to an existing logger, to migrate to OTel logs gradually
+	Tee Logger
+}
+
+// OTelLogger is a Logger emitting OpenTelemetry log records
+// Records carry the trace and span IDs of the span in the log context, so request logs are
+// correlated with their traces. Fields become record attributes.
+//
+// The logger can be created before the platform: with the default provider, records are
+// dropped until telemetry is initialized, while the tee logger receives every entry.
+//
+// Example:
+//
+//	logger := httpplatform.NewOTelLogger(httpplatform.OTelLoggerConfig{Tee: lokiLogger})
+//	defer logger.Close()
+//
+//	platform, _ := httpplatform.New(cfg,
+//	    httpplatform.WithLogger(logger),
+//	    httpplatform.WithTelemetry("orders", "1.0.0", "production", "localhost:4318"),
+//	)
+type OTelLogger struct {
+	logger log.Logger
+	tee    Logger
+}
+
+// NewOTelLogger creates a Logger emitting OpenTelemetry log records
+func NewOTelLogger(cfg OTelLoggerConfig) *OTelLogger {
+	if cfg.Name == "" {
+		cfg.Name = "http-platform"
+	}
+	provider := cfg.Provider
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+
+	return &OTelLogger{
+		logger: provider.Logger(cfg.Name),
+		tee:    cfg.Tee,
+	}
+}
+
+// Debug logs a debug message with optional fields
+func (l *OTelLogger) Debug(ctx context.Context, msg string, fields Fields) {
+	l.emit(ctx, log.SeverityDebug, "DEBUG", msg, fields)
+	if l.tee != nil {
+		l.tee.Debug(ctx, msg, fields)
+	}
+}
+
+// Info logs an informational message with optional fields
+func (l *OTelLogger) Info(ctx context.Context, msg string, fields Fields) {
+	l.emit(ctx, log.SeverityInfo, "INFO", msg, fields)
+	if l.tee != nil {
+		l.tee.Info(ctx, msg, fields)
+	}
+}
+
+// Warn logs a warning message with optional fields
+func (l *OTelLogger) Warn(ctx context.Context, msg string, fields Fields) {
+	l.emit(ctx, log.SeverityWarn, "WARN", msg, fields)
+	if l.tee != nil {
+		l.tee.Warn(ctx, msg, fields)
+	}
+}
+
+// Error logs an error message with optional fields
+func (l *OTelLogger) Error(ctx context.Context, msg string, fields Fields) {
+	l.emit(ctx, log.SeverityError, "ERROR", msg, fields)
+	if l.tee != nil {
+		l.tee.Error(ctx, msg, fields)
+	}
+}
+
+// Close closes the tee logger, if any
+// Pending records are flushed when the platform shuts down telemetry.
+func (l *OTelLogger) Close() error {
+	if l.tee != nil {
+		return l.tee.Close()
+	}
+	return nil
+}
+
+// emit emits a log record, with the trace context of ctx
+func (l *OTelLogger) emit(ctx context.Context, severity log.Severity, severityText, msg string, fields Fields) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	var record log.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText)
+	record.SetBody(log.StringValue(msg))
+	record.AddAttributes(logKeyValues(fields)...)
+
+	l.logger.Emit(ctx, record)
+}
+
+// logValue converts a field value to a log attribute value
+func logValue(value any) log.Value {
+	switch v := value.(type) {
+	case nil:
+		return log.Value{}
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case []byte:
+		return log.BytesValue(v)
+	case time.Duration:
+		return log.StringValue(v.String())
+	case time.Time:
+		return log.StringValue(v.Format(time.RFC3339Nano))
+	case error:
+		return log.StringValue(v.Error())
+	case fmt.Stringer:
+		return log.StringValue(v.String())
+	case []string:
+		values := make([]log.Value, len(v))
+		for i, s := range v {
+			values[i] = log.StringValue(s)
+		}
+		return log.SliceValue(values...)
+	case []any:
+		values := make([]log.Value, len(v))
+		for i, item := range v {
+			values[i] = logValue(item)
+		}
+		return log.SliceValue(values...)
+	case Fields:
+		return log.MapValue(logKeyValues(v)...)
+	case map[string]any:
+		return log.MapValue(logKeyValues(v)...)
+	default:
+		return log.StringValue(fmt.Sprint(v))
+	}
+}
+
+// logKeyValues converts fields to log key-values, sorted by key for a stable order
+func logKeyValues(fields map[string]any) []log.KeyValue {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]log.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, log.KeyValue{Key: key, Value: logValue(fields[key])})
+	}
+	return kvs
+}
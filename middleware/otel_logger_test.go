@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingProcessor keeps the emitted log records
+type recordingProcessor struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (p *recordingProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, record.Clone())
+	return nil
+}
+
+func (p *recordingProcessor) Shutdown(context.Context) error   { return nil }
+func (p *recordingProcessor) ForceFlush(context.Context) error { return nil }
+
+func TestOTelLoggerEmitsCorrelatedRecords(t *testing.T) {
+	processor := &recordingProcessor{}
+	tee := &testLogger{}
+	logger := NewOTelLogger(OTelLoggerConfig{
+		Provider: sdklog.NewLoggerProvider(sdklog.WithProcessor(processor)),
+		Tee:      tee,
+	})
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+	logger.Warn(ctx, "Client error", Fields{
+		"status":   404,
+		"latency":  1500 * time.Millisecond,
+		"error":    errors.New("order not found"),
+		"request":  Fields{"method": "GET"},
+		"internal": true,
+	})
+
+	if len(processor.records) != 1 {
+		t.Fatalf("emitted %d records, want 1", len(processor.records))
+	}
+	record := processor.records[0]
+	if record.Severity() != log.SeverityWarn || record.SeverityText() != "WARN" || record.Body().AsString() != "Client error" {
+		t.Errorf("record = %v %q %q, want WARN \"Client error\"", record.Severity(), record.SeverityText(), record.Body().AsString())
+	}
+	if record.TraceID() != spanCtx.TraceID() || record.SpanID() != spanCtx.SpanID() {
+		t.Errorf("record trace = %s/%s, want %s/%s", record.TraceID(), record.SpanID(), spanCtx.TraceID(), spanCtx.SpanID())
+	}
+
+	attrs := map[string]log.Value{}
+	var keys []string
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		keys = append(keys, kv.Key)
+		return true
+	})
+	if got := attrs["status"]; got.Kind() != log.KindInt64 || got.AsInt64() != 404 {
+		t.Errorf("status = %v, want int 404", got)
+	}
+	if got := attrs["latency"].AsString(); got != "1.5s" {
+		t.Errorf("latency = %q, want 1.5s", got)
+	}
+	if got := attrs["error"].AsString(); got != "order not found" {
+		t.Errorf("error = %q, want the error message", got)
+	}
+	if got := attrs["request"]; got.Kind() != log.KindMap || len(got.AsMap()) != 1 || got.AsMap()[0].Value.AsString() != "GET" {
+		t.Errorf("request = %v, want a map with method GET", got)
+	}
+	if want := []string{"error", "internal", "latency", "request", "status"}; !slices.Equal(keys, want) {
+		t.Errorf("attribute keys = %v, want sorted %v", keys, want)
+	}
+
+	if entries := tee.find("Client error"); len(entries) != 1 || entries[0].level != "warn" {
+		t.Errorf("tee entries = %v, want the warning", entries)
+	}
+}
+
+// minSeverityProcessor records only the records at or above a severity
+type minSeverityProcessor struct {
+	recordingProcessor
+	min log.Severity
+}
+
+func (p *minSeverityProcessor) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= p.min
+}
+
+func TestOTelLoggerSkipsDisabledSeverities(t *testing.T) {
+	processor := &minSeverityProcessor{min: log.SeverityInfo}
+	tee := &testLogger{}
+	logger := NewOTelLogger(OTelLoggerConfig{
+		Provider: sdklog.NewLoggerProvider(sdklog.WithProcessor(processor)),
+		Tee:      tee,
+	})
+
+	logger.Debug(context.Background(), "Request body", nil)
+	logger.Info(context.Background(), "Request completed", nil)
+
+	if len(processor.records) != 1 || processor.records[0].Body().AsString() != "Request completed" {
+		t.Errorf("emitted %v, want only the info record", processor.records)
+	}
+	if len(tee.find("Request body")) != 1 {
+		t.Error("tee logger missed the debug entry")
+	}
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media types of RFC 9457 problem details
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+)
+
+// ProblemDetails is an RFC 9457 problem details error response
+// It is rendered by ErrorHandler when configured with WithProblemDetails.
+type ProblemDetails struct {
+	// Type is a URI identifying the problem type ("about:blank" when no type base URI is configured)
+	Type string `json:"type"`
+
+	// Title is a short summary of the problem type (the HTTP status text)
+	Title string `json:"title"`
+
+	// Status is the HTTP status code
+	Status int `json:"status"`
+
+	// Detail explains this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies this occurrence (the request path)
+	Instance string `json:"instance,omitempty"`
+
+	// TraceID is an extension member holding the request trace ID
+	TraceID string `json:"trace_id,omitempty"`
+
+	// Code is an extension member holding the domain error code
+	Code string `json:"code,omitempty"`
+
+	// Details is an extension member holding the domain error metadata
+	Details ErrorDetails `json:"details,omitempty"`
+
+	// InvalidParams is an extension member listing validation errors
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+
+	// Errors is an extension member listing every error of the request (WithErrorAggregation)
+	Errors []ProblemDetails `json:"errors,omitempty"`
+}
+
+// MarshalXML encodes the problem in the RFC 9457 XML format (array items as <i> elements)
+func (p ProblemDetails) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type invalidParams struct {
+		Items []InvalidParam `xml:"i"`
+	}
+	type problemErrors struct {
+		Items []ProblemDetails `xml:"i"`
+	}
+	type problemXML struct {
+		Type          string         `xml:"type"`
+		Title         string         `xml:"title"`
+		Status        int            `xml:"status"`
+		Detail        string         `xml:"detail,omitempty"`
+		Instance      string         `xml:"instance,omitempty"`
+		TraceID       string         `xml:"trace_id,omitempty"`
+		Code          string         `xml:"code,omitempty"`
+		Details       ErrorDetails   `xml:"details,omitempty"`
+		InvalidParams *invalidParams `xml:"invalid-params,omitempty"`
+		Errors        *problemErrors `xml:"errors,omitempty"`
+	}
+
+	out := problemXML{
+		Type:     p.Type,
+		Title:    p.Title,
+		Status:   p.Status,
+		Detail:   p.Detail,
+		Instance: p.Instance,
+		TraceID:  p.TraceID,
+		Code:     p.Code,
+		Details:  p.Details,
+	}
+	if len(p.InvalidParams) > 0 {
+		out.InvalidParams = &invalidParams{Items: p.InvalidParams}
+	}
+	if len(p.Errors) > 0 {
+		out.Errors = &problemErrors{Items: p.Errors}
+	}
+
+	// Nested problems are array items; the document root is the RFC 9457 problem element
+	if start.Name.Local != "i" {
+		start = xml.StartElement{Name: xml.Name{Space: "urn:ietf:rfc:7807", Local: "problem"}}
+	}
+	return e.EncodeElement(out, start)
+}
+
+// InvalidParam describes a single invalid request parameter of a problem
+type InvalidParam struct {
+	Name    string `json:"name" xml:"name"`
+	Reason  string `json:"reason" xml:"reason"`
+	Message string `json:"message,omitempty" xml:"message,omitempty"`
+}
+
+// newProblemDetails converts an ApiError to problem details for the current request
+func newProblemDetails(ctx *gin.Context, apiErr *ApiError, errorType, typeBaseURI string) *ProblemDetails {
+	return problemDetails(ctx.Request.URL.Path, GetTraceID(ctx), apiErr, errorType, typeBaseURI)
+}
+
+// problemDetails converts an ApiError to problem details for the given instance and trace ID
+func problemDetails(instance, traceID string, apiErr *ApiError, errorType, typeBaseURI string) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:     "about:blank",
+		Title:    apiErr.Error,
+		Status:   apiErr.Status,
+		Detail:   apiErr.Message,
+		Instance: instance,
+		TraceID:  traceID,
+		Code:     apiErr.Code,
+		Details:  apiErr.Details,
+	}
+
+	if typeBaseURI != "" && errorType != "" {
+		problem.Type = strings.TrimSuffix(typeBaseURI, "/") + "/" + problemSlug(errorType)
+	}
+	if problem.Title == "" {
+		// Non-standard statuses (e.g., 499) have no status text
+		problem.Title = problem.Detail
+	}
+
+	for _, cause := range apiErr.Cause {
+		switch c := cause.(type) {
+		case []*validationError:
+			for _, v := range c {
+				problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Name: v.Field, Reason: v.Reason, Message: v.Message})
+			}
+		case *validationError:
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Name: c.Field, Reason: c.Reason, Message: c.Message})
+		case *ApiError:
+			// Aggregated errors share the instance and trace ID of the request
+			nested := problemDetails("", "", c, c.errorType, typeBaseURI)
+			problem.Errors = append(problem.Errors, *nested)
+		}
+	}
+
+	return problem
+}
+
+// writeProblem renders an error as problem details in the negotiated format
+// JSON and XML use the problem media types; other formats keep their own Content-Type.
+func writeProblem(ctx *gin.Context, apiErr *ApiError, errorType, typeBaseURI string) {
+	registry := RequestRenderers(ctx)
+	renderer, ok := registry.Negotiate(problemAccept(ctx.GetHeader("Accept")))
+	if !ok {
+		renderer = registry.Default()
+		apiErr = NewApiError(notAcceptableMessage(registry), http.StatusNotAcceptable)
+		errorType = "NotAcceptableError"
+	}
+
+	problem := newProblemDetails(ctx, apiErr, errorType, typeBaseURI)
+	writeErrorHeaders(ctx, apiErr)
+	ctx.Render(problem.Status, negotiatedRender{renderer: problemRenderer{renderer}, value: problem})
+}
+
+// problemAccept maps the problem media types of an Accept header to the registered JSON and XML types
+func problemAccept(accept string) string {
+	return strings.NewReplacer(MIMEProblemJSON, MIMEJSON, MIMEProblemXML, MIMEXML).Replace(strings.ToLower(accept))
+}
+
+// problemRenderer switches JSON and XML renderers to the problem media types
+type problemRenderer struct {
+	renderer Renderer
+}
+
+// ContentType returns the problem media type for JSON and XML, the renderer type otherwise
+func (r problemRenderer) ContentType() string {
+	contentType := r.renderer.ContentType()
+	switch {
+	case strings.HasPrefix(contentType, MIMEJSON):
+		return MIMEProblemJSON
+	case strings.HasPrefix(contentType, MIMEXML), strings.HasPrefix(contentType, MIMEXML2):
+		return MIMEProblemXML
+	default:
+		return contentType
+	}
+}
+
+// Render delegates to the negotiated renderer
+func (r problemRenderer) Render(w io.Writer, v any) error {
+	return r.renderer.Render(w, v)
+}
+
+// problemSlug converts an error type to a problem type path segment
+// e.g., "NotFoundError" becomes "not-found" and "JSONSyntaxError" becomes "json-syntax"
+func problemSlug(errorType string) string {
+	name := []rune(strings.TrimSuffix(errorType, "Error"))
+
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(name[i-1])
+			nextLower := i+1 < len(name) && unicode.IsLower(name[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(name[i-1])) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
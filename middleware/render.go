@@ -0,0 +1,401 @@
+package middleware
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Media types of the built-in renderers
+const (
+	MIMEJSON     = "application/json"
+	MIMEXML      = "application/xml"
+	MIMEXML2     = "text/xml"
+	MIMEMsgPack  = "application/msgpack"
+	MIMEMsgPack2 = "application/x-msgpack"
+	MIMEProtobuf = "application/x-protobuf"
+	MIMECBOR     = "application/cbor"
+)
+
+// Renderer encodes response values in a specific format
+type Renderer interface {
+	// ContentType returns the Content-Type header of rendered responses
+	ContentType() string
+
+	// Render encodes v into w
+	Render(w io.Writer, v any) error
+}
+
+// rendererFunc adapts an encode function to the Renderer interface
+type rendererFunc struct {
+	contentType string
+	encode      func(w io.Writer, v any) error
+}
+
+func (r rendererFunc) ContentType() string { return r.contentType }
+
+func (r rendererFunc) Render(w io.Writer, v any) error { return r.encode(w, v) }
+
+// NewRenderer creates a Renderer from a Content-Type and an encode function
+//
+// Example:
+//
+//	httpplatform.RegisterRenderer("application/yaml", httpplatform.NewRenderer("application/yaml; charset=utf-8",
+//	    func(w io.Writer, v any) error { return yaml.NewEncoder(w).Encode(v) }))
+func NewRenderer(contentType string, encode func(w io.Writer, v any) error) Renderer {
+	return rendererFunc{contentType: contentType, encode: encode}
+}
+
+// RenderersKey is the context key of the RendererRegistry used by Render and ErrorHandler
+// It is set by ErrorHandler when created with WithRenderers; Renderers is used otherwise.
+const RenderersKey = "renderers"
+
+// RendererRegistry maps media types to renderers and negotiates them from Accept headers
+// The first registered media type is the default, used when the request has no Accept header.
+type RendererRegistry struct {
+	mu         sync.RWMutex
+	mediaTypes []string // Registration order, used to resolve wildcards
+	renderers  map[string]Renderer
+}
+
+// NewRendererRegistry creates an empty registry
+func NewRendererRegistry() *RendererRegistry {
+	return &RendererRegistry{renderers: make(map[string]Renderer)}
+}
+
+// Register adds or replaces the renderer for a media type (e.g., "application/yaml")
+func (r *RendererRegistry) Register(mediaType string, renderer Renderer) {
+	mediaType = strings.ToLower(mediaType)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.renderers[mediaType]; !exists {
+		r.mediaTypes = append(r.mediaTypes, mediaType)
+	}
+	r.renderers[mediaType] = renderer
+}
+
+// Clone returns a copy of the registry; registering in either one does not affect the other
+func (r *RendererRegistry) Clone() *RendererRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clone := &RendererRegistry{
+		mediaTypes: slices.Clone(r.mediaTypes),
+		renderers:  make(map[string]Renderer, len(r.renderers)),
+	}
+	for mediaType, renderer := range r.renderers {
+		clone.renderers[mediaType] = renderer
+	}
+	return clone
+}
+
+// MediaTypes returns the registered media types in registration order
+func (r *RendererRegistry) MediaTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.mediaTypes)
+}
+
+// Default returns the renderer of the first registered media type
+func (r *RendererRegistry) Default() Renderer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.mediaTypes) == 0 {
+		return jsonRenderer
+	}
+	return r.renderers[r.mediaTypes[0]]
+}
+
+// Negotiate picks the renderer for an Accept header (RFC 9110 proactive negotiation)
+// Each registered media type gets the quality of the most specific range matching it; the
+// highest quality wins, exact types before "type/*" and "*/*". The default renderer is preferred:
+//   - when the best match comes only from a wildcard, or ties with the default listed explicitly
+//   - when the most preferred ranges match no registered type, as with browsers sending
+//     "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8": their preference is
+//     a page, not one of the API formats
+//
+// An empty header selects the default renderer. Returns false when no registered media type is
+// acceptable.
+func (r *RendererRegistry) Negotiate(accept string) (Renderer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.mediaTypes) == 0 {
+		return jsonRenderer, true
+	}
+	defaultType := r.mediaTypes[0]
+	if strings.TrimSpace(accept) == "" {
+		return r.renderers[defaultType], true
+	}
+
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return r.renderers[defaultType], true
+	}
+
+	best, bestMatch := "", acceptMatch{}
+	for _, mediaType := range r.mediaTypes {
+		if match := matchAccept(ranges, mediaType); match.better(bestMatch) {
+			best, bestMatch = mediaType, match
+		}
+	}
+	if bestMatch.q == 0 {
+		return nil, false
+	}
+
+	if best != defaultType {
+		defaultMatch := matchAccept(ranges, defaultType)
+		preferDefault := defaultMatch.q > 0 &&
+			(!bestMatch.exact || (defaultMatch.exact && defaultMatch.q == bestMatch.q) || bestMatch.q < ranges[0].q)
+		if preferDefault {
+			return r.renderers[defaultType], true
+		}
+	}
+	return r.renderers[best], true
+}
+
+// acceptMatch is how an Accept header accepts a media type
+type acceptMatch struct {
+	q     float64
+	exact bool // Matched by the media type itself rather than a wildcard
+	index int  // Position of the matching range, lower is preferred by the client
+}
+
+// better reports whether the match is preferred over other
+// Equal matches keep other, so ties go to the earlier registered media type.
+func (m acceptMatch) better(other acceptMatch) bool {
+	switch {
+	case m.q != other.q:
+		return m.q > other.q
+	case m.exact != other.exact:
+		return m.exact
+	default:
+		return m.index < other.index
+	}
+}
+
+// matchAccept returns the match of the most specific range accepting the media type, or a zero
+// quality when no range does (RFC 9110 section 12.5.1)
+func matchAccept(ranges []mediaRange, mediaType string) acceptMatch {
+	match, specificity := acceptMatch{}, -1
+	for i, mr := range ranges {
+		if mr.matches(mediaType) && mr.specificity() > specificity {
+			match = acceptMatch{q: mr.q, exact: mr.specificity() == 2, index: i}
+			specificity = mr.specificity()
+		}
+	}
+	return match
+}
+
+// mediaRange is a single entry of an Accept header
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// matches reports whether the range accepts the media type
+func (mr mediaRange) matches(mediaType string) bool {
+	switch {
+	case mr.mediaType == "*/*":
+		return true
+	case strings.HasSuffix(mr.mediaType, "/*"):
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(mr.mediaType, "*"))
+	default:
+		return mr.mediaType == mediaType
+	}
+}
+
+// specificity ranks exact types above "type/*" above "*/*"
+func (mr mediaRange) specificity() int {
+	switch {
+	case mr.mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mr.mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// parseAccept parses an Accept header, ordered by preference
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 && parsed <= 1 {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+	return ranges
+}
+
+// Built-in renderers
+var (
+	jsonRenderer = NewRenderer("application/json; charset=utf-8", func(w io.Writer, v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+
+	xmlRenderer = NewRenderer("application/xml; charset=utf-8", func(w io.Writer, v any) error {
+		return xml.NewEncoder(w).Encode(v)
+	})
+
+	msgpackRenderer = NewRenderer("application/msgpack", func(w io.Writer, v any) error {
+		var handle codec.MsgpackHandle
+		return codec.NewEncoder(w, &handle).Encode(v)
+	})
+
+	cborRenderer = NewRenderer("application/cbor", func(w io.Writer, v any) error {
+		return cbor.NewEncoder(w).Encode(v)
+	})
+
+	protobufRenderer = NewRenderer("application/x-protobuf", renderProtobuf)
+)
+
+// renderProtobuf encodes proto messages as is; other values (e.g., ApiError) are
+// converted through their JSON form and encoded as google.protobuf.Struct or Value
+func renderProtobuf(w io.Writer, v any) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+
+		if object, isObject := generic.(map[string]any); isObject {
+			message, err = structpb.NewStruct(object)
+		} else {
+			message, err = structpb.NewValue(generic)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Renderers is the registry used by Render and ErrorHandler
+// JSON is the default; XML, MessagePack, Protobuf and CBOR are registered as well.
+var Renderers = newDefaultRendererRegistry()
+
+// newDefaultRendererRegistry creates a registry with the built-in renderers
+func newDefaultRendererRegistry() *RendererRegistry {
+	registry := NewRendererRegistry()
+	registry.Register(MIMEJSON, jsonRenderer)
+	registry.Register(MIMEXML, xmlRenderer)
+	registry.Register(MIMEXML2, xmlRenderer)
+	registry.Register(MIMEMsgPack, msgpackRenderer)
+	registry.Register(MIMEMsgPack2, msgpackRenderer)
+	registry.Register(MIMEProtobuf, protobufRenderer)
+	registry.Register(MIMECBOR, cborRenderer)
+	return registry
+}
+
+// RegisterRenderer adds or replaces a renderer in the default registry
+// Platforms copy the default registry when created, so formats registered afterwards only
+// reach platforms created afterwards; use Platform.Renderers to add formats to one platform.
+func RegisterRenderer(mediaType string, renderer Renderer) {
+	Renderers.Register(mediaType, renderer)
+}
+
+// RequestRenderers returns the registry of the request: the one set by ErrorHandler (see
+// WithRenderers), or Renderers
+func RequestRenderers(c *gin.Context) *RendererRegistry {
+	if value, ok := c.Get(RenderersKey); ok {
+		if registry, ok := value.(*RendererRegistry); ok {
+			return registry
+		}
+	}
+	return Renderers
+}
+
+// NegotiateRenderer picks the renderer for the request Accept header
+func NegotiateRenderer(c *gin.Context) (Renderer, bool) {
+	return RequestRenderers(c).Negotiate(c.GetHeader("Accept"))
+}
+
+// Render writes v with the given status in the format negotiated from the Accept header
+// When no registered format is acceptable, a NotAcceptableError is passed to ErrorHandler (406).
+//
+// Example:
+//
+//	httpplatform.Render(c, http.StatusOK, user) // JSON, XML, MessagePack, Protobuf or CBOR
+func Render(c *gin.Context, status int, v any) {
+	renderer, ok := NegotiateRenderer(c)
+	if !ok {
+		c.Error(platformErrors.NewNotAcceptableError(notAcceptableMessage(RequestRenderers(c))))
+		c.Abort()
+		return
+	}
+	c.Render(status, negotiatedRender{renderer: renderer, value: v})
+}
+
+// notAcceptableMessage lists the media types supported by the registry
+func notAcceptableMessage(registry *RendererRegistry) string {
+	return fmt.Sprintf("None of the accepted media types is supported (supported: %s)",
+		strings.Join(registry.MediaTypes(), ", "))
+}
+
+// negotiatedRender adapts a Renderer to gin's render.Render
+type negotiatedRender struct {
+	renderer Renderer
+	value    any
+}
+
+// Render encodes the value into the response
+func (r negotiatedRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return r.renderer.Render(w, r.value)
+}
+
+// WriteContentType sets the Content-Type header of the renderer
+func (r negotiatedRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", r.renderer.ContentType())
+}
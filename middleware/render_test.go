@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRendererRegistryNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string // Content-Type of the selected renderer, "" for none
+	}{
+		{"no header", "", jsonRenderer.ContentType()},
+		{"browser", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", jsonRenderer.ContentType()},
+		{"wildcard", "*/*", jsonRenderer.ContentType()},
+		{"explicit xml", "application/xml", xmlRenderer.ContentType()},
+		{"explicit tie", "application/xml, application/json", jsonRenderer.ContentType()},
+		{"explicit over wildcard", "application/xml, */*", xmlRenderer.ContentType()},
+		{"quality", "application/json;q=0.5, application/cbor", cborRenderer.ContentType()},
+		{"type wildcard", "text/*, application/json;q=0.1", jsonRenderer.ContentType()},
+		{"json refused", "text/html, application/xml;q=0.9, application/json;q=0, */*;q=0.8", xmlRenderer.ContentType()},
+		{"json not acceptable", "text/html, application/xml;q=0.9", xmlRenderer.ContentType()},
+		{"most specific range wins", "application/*;q=0, application/cbor, */*", cborRenderer.ContentType()},
+		{"nothing acceptable", "text/html", ""},
+	}
+	registry := newDefaultRendererRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, ok := registry.Negotiate(tt.accept)
+			got := ""
+			if ok {
+				got = renderer.ContentType()
+			}
+			if got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRenderersScopesFormats(t *testing.T) {
+	registry := Renderers.Clone()
+	registry.Register("application/yaml", NewRenderer("application/yaml", func(w io.Writer, v any) error {
+		_, err := io.WriteString(w, "yaml")
+		return err
+	}))
+
+	gin.SetMode(gin.TestMode)
+	scoped := gin.New()
+	scoped.Use(ErrorHandler(&testLogger{}, WithRenderers(registry)))
+	scoped.GET("/", func(c *gin.Context) { Render(c, http.StatusOK, "value") })
+
+	unscoped := gin.New()
+	unscoped.Use(ErrorHandler(&testLogger{}))
+	unscoped.GET("/", func(c *gin.Context) { Render(c, http.StatusOK, "value") })
+
+	for _, tt := range []struct {
+		engine *gin.Engine
+		want   int
+	}{{scoped, http.StatusOK}, {unscoped, http.StatusNotAcceptable}} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "application/yaml")
+		tt.engine.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body.String())
+		}
+	}
+	if _, ok := Renderers.Negotiate("application/yaml"); ok {
+		t.Error("format registered on a clone reached the default registry")
+	}
+}
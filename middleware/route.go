@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteConfigKey is the context key for storing the matched route configuration
+const RouteConfigKey = "route_config"
+
+// RouteConfig holds per-route settings attached at registration time
+// Built-in middleware reads it through GetRouteConfig to adapt its behavior per route.
+type RouteConfig struct {
+	// Timeout enforces a route-specific timeout (see WithTimeout). Zero means no timeout.
+	Timeout time.Duration
+
+	// MaxBodyBytes limits the request body size (see MaxBodySize). Zero means no limit.
+	MaxBodyBytes int64
+
+	// NoLog suppresses the request log line for successful requests (e.g., health checks)
+	// Requests completing with 4xx/5xx are still logged.
+	NoLog bool
+
+	// Tags are free-form labels included in logs (e.g., "public", "billing")
+	// They are also used as OpenAPI operation tags.
+	Tags []string
+
+	// Summary and Description document the route in the generated OpenAPI document
+	Summary     string
+	Description string
+
+	// Request is a value of the request type (e.g., CreateOrder{}) used to document
+	// path (uri tag), query (form tag), header (header tag) and JSON body parameters
+	Request any
+
+	// Responses documents the possible successful responses by status code
+	Responses []RouteResponse
+
+	// Hidden excludes the route from the generated OpenAPI document
+	Hidden bool
+}
+
+// RouteResponse documents a response of a route
+type RouteResponse struct {
+	// Status is the HTTP status code (e.g., 200, 201, 204)
+	Status int
+
+	// Body is a value of the response type, or nil for responses without a body
+	Body any
+}
+
+// RouteOption configures a RouteConfig
+type RouteOption func(*RouteConfig)
+
+// RouteOptions groups the route option constructors
+// Use it through the Route variable: Route.Timeout(5*time.Second), Route.NoLog(), ...
+type RouteOptions struct{}
+
+// Route provides the route option constructors
+//
+// Example:
+//
+//	platform.Handle(http.MethodPost, "/reports", createReport,
+//	    httpplatform.Route.Timeout(30*time.Second),
+//	    httpplatform.Route.MaxBody(10<<20),
+//	    httpplatform.Route.Tags("reports"),
+//	)
+var Route RouteOptions
+
+// Timeout sets a route-specific timeout
+func (RouteOptions) Timeout(timeout time.Duration) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Timeout = timeout
+	}
+}
+
+// MaxBody limits the request body to the given number of bytes
+func (RouteOptions) MaxBody(bytes int64) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.MaxBodyBytes = bytes
+	}
+}
+
+// NoLog disables request logging for successful requests on the route
+func (RouteOptions) NoLog() RouteOption {
+	return func(rc *RouteConfig) {
+		rc.NoLog = true
+	}
+}
+
+// Tags attaches labels to the route
+func (RouteOptions) Tags(tags ...string) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Tags = append(rc.Tags, tags...)
+	}
+}
+
+// Summary sets a short summary for the OpenAPI operation
+func (RouteOptions) Summary(summary string) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Summary = summary
+	}
+}
+
+// Description sets a longer description for the OpenAPI operation
+func (RouteOptions) Description(description string) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Description = description
+	}
+}
+
+// Request declares the request type (pass a zero value, e.g. CreateOrder{})
+func (RouteOptions) Request(request any) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Request = request
+	}
+}
+
+// Response declares a response type for the given status (body may be nil, e.g. for 204)
+func (RouteOptions) Response(status int, body any) RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Responses = append(rc.Responses, RouteResponse{Status: status, Body: body})
+	}
+}
+
+// Hidden excludes the route from the generated OpenAPI document
+func (RouteOptions) Hidden() RouteOption {
+	return func(rc *RouteConfig) {
+		rc.Hidden = true
+	}
+}
+
+// NewRouteConfig builds a RouteConfig from the given options
+func NewRouteConfig(opts ...RouteOption) *RouteConfig {
+	rc := &RouteConfig{}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+// RouteRegistry stores the configuration of routes registered with options
+// Routes are keyed by HTTP method and full path template (as returned by c.FullPath()).
+type RouteRegistry struct {
+	mu     sync.RWMutex
+	routes map[string]*RouteConfig
+}
+
+// NewRouteRegistry creates an empty route registry
+func NewRouteRegistry() *RouteRegistry {
+	return &RouteRegistry{routes: make(map[string]*RouteConfig)}
+}
+
+// Register stores the configuration for a route
+func (r *RouteRegistry) Register(method, fullPath string, rc *RouteConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[method+" "+fullPath] = rc
+}
+
+// Lookup returns the configuration for a route, or nil if none was registered
+func (r *RouteRegistry) Lookup(method, fullPath string) *RouteConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.routes[method+" "+fullPath]
+}
+
+// RouteMetadata creates a middleware that exposes the matched route configuration
+// through GetRouteConfig. It must run first so every other middleware can read it.
+func RouteMetadata(registry *RouteRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if fullPath := c.FullPath(); fullPath != "" {
+			if rc := registry.Lookup(c.Request.Method, fullPath); rc != nil {
+				c.Set(RouteConfigKey, rc)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// GetRouteConfig extracts the matched route configuration from the gin context
+// Returns nil if the route was registered without options
+func GetRouteConfig(c *gin.Context) *RouteConfig {
+	if rc, exists := c.Get(RouteConfigKey); exists {
+		if cfg, ok := rc.(*RouteConfig); ok {
+			return cfg
+		}
+	}
+	return nil
+}
+
+// MaxBodySize creates a middleware that limits the request body size
+// Requests declaring a larger Content-Length are rejected immediately with 413;
+// otherwise reading past the limit fails with *http.MaxBytesError, which ErrorHandler maps to 413.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.Error(&http.MaxBytesError{Limit: limit})
+			c.Abort()
+			return
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of exception events recorded by ErrorHandler, matching its log fields
+const (
+	spanErrorTypeKey = attribute.Key("error_type")
+	spanStatusKey    = attribute.Key("status")
+	spanPanicKey     = attribute.Key("panic")
+)
+
+// Span attributes of the request identifiers
+const (
+	spanUserIDKey   = attribute.Key("enduser.id")
+	spanTenantIDKey = attribute.Key("tenant.id")
+)
+
+// SpanAttributesConfig configures the request attributes added to the request span
+type SpanAttributesConfig struct {
+	// Headers are recorded as http.request.header.<lowercase name> (avoid credentials such as Authorization)
+	Headers []string
+
+	// QueryParams are recorded as http.request.query.<name>
+	QueryParams []string
+
+	// UserID returns the user of the request, recorded as enduser.id when not empty
+	// It is called after the handlers, so it can read values set by authentication middleware.
+	UserID func(c *gin.Context) string
+
+	// TenantID returns the tenant of the request, recorded as tenant.id when not empty
+	// It is called after the handlers, like UserID.
+	TenantID func(c *gin.Context) string
+}
+
+// IsZero reports whether no attribute is configured
+func (cfg SpanAttributesConfig) IsZero() bool {
+	return len(cfg.Headers) == 0 && len(cfg.QueryParams) == 0 && cfg.UserID == nil && cfg.TenantID == nil
+}
+
+// requestAttribute is a header or query parameter recorded on the span
+type requestAttribute struct {
+	name string
+	key  attribute.Key
+}
+
+// SpanAttributes returns a middleware adding request headers, query parameters and the
+// user and tenant identifiers to the active span
+// It must run after Telemetry, which starts the span; nothing is recorded on unsampled requests.
+func SpanAttributes(cfg SpanAttributesConfig) gin.HandlerFunc {
+	headers := make([]requestAttribute, 0, len(cfg.Headers))
+	for _, name := range cfg.Headers {
+		headers = append(headers, requestAttribute{
+			name: http.CanonicalHeaderKey(name),
+			key:  attribute.Key("http.request.header." + strings.ToLower(name)),
+		})
+	}
+	params := make([]requestAttribute, 0, len(cfg.QueryParams))
+	for _, name := range cfg.QueryParams {
+		params = append(params, requestAttribute{name: name, key: attribute.Key("http.request.query." + name)})
+	}
+
+	return func(c *gin.Context) {
+		span := trace.SpanFromContext(c.Request.Context())
+		if !span.IsRecording() {
+			c.Next()
+			return
+		}
+
+		var attrs []attribute.KeyValue
+		for _, header := range headers {
+			if values := c.Request.Header.Values(header.name); len(values) > 0 {
+				attrs = append(attrs, header.key.StringSlice(values))
+			}
+		}
+		if len(params) > 0 {
+			query := c.Request.URL.Query()
+			for _, param := range params {
+				if values, ok := query[param.name]; ok {
+					attrs = append(attrs, param.key.StringSlice(values))
+				}
+			}
+		}
+		span.SetAttributes(attrs...)
+
+		c.Next()
+
+		if cfg.UserID != nil {
+			if userID := cfg.UserID(c); userID != "" {
+				span.SetAttributes(spanUserIDKey.String(userID))
+			}
+		}
+		if cfg.TenantID != nil {
+			if tenantID := cfg.TenantID(c); tenantID != "" {
+				span.SetAttributes(spanTenantIDKey.String(tenantID))
+			}
+		}
+	}
+}
+
+// recordSpanException records an error handled by ErrorHandler (or a panic value) as an
+// exception event of the active span, with its error_type and status, and marks the span as
+// failed on 5xx
+// The stack is recorded for panics. Reports whether the span records the request.
+func recordSpanException(ctx *gin.Context, value any, message, errorType string, status int, stack []byte) bool {
+	span := trace.SpanFromContext(ctx.Request.Context())
+	if !span.IsRecording() {
+		return false
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ExceptionType(fmt.Sprintf("%T", value)),
+		semconv.ExceptionMessage(message),
+		spanErrorTypeKey.String(errorType),
+		spanStatusKey.Int(status),
+	}
+	if stack != nil {
+		attrs = append(attrs, spanPanicKey.Bool(true), semconv.ExceptionStacktrace(string(stack)))
+	}
+	span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(attrs...))
+
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, message)
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var errBadInput = errors.New("bad input")
+
+// useSpanRecorder installs a tracer provider recording the spans for the test
+func useSpanRecorder(t *testing.T, opts ...sdktrace.TracerProviderOption) *tracetest.SpanRecorder {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	previousTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(append(opts, sdktrace.WithSpanProcessor(spans))...))
+	t.Cleanup(func() { otel.SetTracerProvider(previousTP) })
+	return spans
+}
+
+// spanAttribute returns the value of an attribute of the last ended span
+func spanAttribute(spans *tracetest.SpanRecorder, key attribute.Key) (attribute.Value, bool) {
+	ended := spans.Ended()
+	for _, attr := range ended[len(ended)-1].Attributes() {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestSpanAttributes(t *testing.T) {
+	spans := useSpanRecorder(t)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Telemetry("test"), SpanAttributes(SpanAttributesConfig{
+		Headers:     []string{"x-client-version", "X-Missing"},
+		QueryParams: []string{"tag", "missing"},
+		UserID:      func(c *gin.Context) string { return c.GetString("user_id") },
+		TenantID:    func(c *gin.Context) string { return c.GetString("tenant_id") },
+	}))
+	engine.GET("/orders", func(c *gin.Context) {
+		c.Set("user_id", "u-42")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?tag=a&tag=b", nil)
+	req.Header.Set("X-Client-Version", "2.1.0")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got, _ := spanAttribute(spans, "http.request.header.x-client-version"); !slices.Equal(got.AsStringSlice(), []string{"2.1.0"}) {
+		t.Errorf("header attribute = %v, want [2.1.0]", got.AsStringSlice())
+	}
+	if got, _ := spanAttribute(spans, "http.request.query.tag"); !slices.Equal(got.AsStringSlice(), []string{"a", "b"}) {
+		t.Errorf("query attribute = %v, want [a b]", got.AsStringSlice())
+	}
+	if got, _ := spanAttribute(spans, spanUserIDKey); got.AsString() != "u-42" {
+		t.Errorf("%s = %q, want the user set by the handler", spanUserIDKey, got.AsString())
+	}
+	for _, key := range []attribute.Key{"http.request.header.x-missing", "http.request.query.missing", spanTenantIDKey} {
+		if _, ok := spanAttribute(spans, key); ok {
+			t.Errorf("%s recorded for an absent value", key)
+		}
+	}
+}
+
+func TestSpanExceptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		errorType  string
+		status     int
+		panic      bool
+		spanFailed bool
+	}{
+		{"client error", func(c *gin.Context) {
+			_ = c.Error(platformErrors.NewNotFoundError("Order not found"))
+		}, "NotFoundError", http.StatusNotFound, false, false},
+		{"server error", func(c *gin.Context) { _ = c.Error(errBadInput) }, "UnknownError", http.StatusInternalServerError, false, true},
+		{"panic", func(c *gin.Context) { panic("boom") }, "Panic", http.StatusInternalServerError, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans := useSpanRecorder(t)
+			engine := newSpanEngine(tt.handler)
+			engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			ended := spans.Ended()
+			span := ended[len(ended)-1]
+			if len(span.Events()) != 1 || span.Events()[0].Name != semconv.ExceptionEventName {
+				t.Fatalf("events = %v, want one exception event", span.Events())
+			}
+			attrs := attribute.NewSet(span.Events()[0].Attributes...)
+			if got, _ := attrs.Value(spanErrorTypeKey); got.AsString() != tt.errorType {
+				t.Errorf("error_type = %q, want %q", got.AsString(), tt.errorType)
+			}
+			if got, _ := attrs.Value(spanStatusKey); got.AsInt64() != int64(tt.status) {
+				t.Errorf("status = %d, want %d", got.AsInt64(), tt.status)
+			}
+			if _, ok := attrs.Value(semconv.ExceptionStacktraceKey); ok != tt.panic {
+				t.Errorf("stack trace recorded = %v, want %v", ok, tt.panic)
+			}
+			if failed := span.Status().Code == codes.Error; failed != tt.spanFailed {
+				t.Errorf("span failed = %v, want %v", failed, tt.spanFailed)
+			}
+		})
+	}
+}
+
+func TestSpanExceptionSkipsUnsampledRequests(t *testing.T) {
+	spans := useSpanRecorder(t, sdktrace.WithSampler(sdktrace.NeverSample()))
+	engine := newSpanEngine(func(c *gin.Context) { _ = c.Error(errBadInput) })
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if ended := spans.Ended(); len(ended) != 0 {
+		t.Errorf("recorded %d spans of an unsampled request, want 0", len(ended))
+	}
+}
+
+func newSpanEngine(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Telemetry("test"), TelemetryErrorFilter(), ErrorHandler(&testLogger{}))
+	engine.GET("/", handler)
+	return engine
+}
@@ -1,12 +1,98 @@
 package middleware
 
 import (
+	"context"
+
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Telemetry returns a middleware that traces HTTP requests using OpenTelemetry
+// It also records the HTTP server semantic-convention metrics (http.server.request.duration,
+// http.server.request.body.size, http.server.response.body.size) with the global meter provider.
 // serviceName should match the service name configured in telemetry initialization
+//
+// Requests without an inbound trace context but with a valid X-Trace-Id header start their
+// trace with that trace ID when the tracer provider generates IDs with TraceIDGenerator, as the
+// platform's does.
 func Telemetry(serviceName string) gin.HandlerFunc {
-	return otelgin.Middleware(serviceName)
+	traced := otelgin.Middleware(serviceName)
+	return func(c *gin.Context) {
+		seedTraceID(c)
+		traced(c)
+
+		// Give back the errors hidden by TelemetryErrorFilter to the middleware before Telemetry
+		if hidden, ok := c.Get(hiddenErrorsKey); ok {
+			c.Errors = hidden.([]*gin.Error)
+		}
+	}
+}
+
+// Context keys of the errors ErrorHandler recorded on the span
+const (
+	recordedErrorsKey = "telemetry_errors_recorded"
+	hiddenErrorsKey   = "telemetry_errors_hidden"
+)
+
+// TelemetryErrorFilter returns a middleware hiding the errors ErrorHandler recorded on the
+// span from Telemetry, which would record them again and mark client errors (4xx) as span
+// failures; install it right after Telemetry
+// c.Errors is only emptied while Telemetry finishes the span and restored when it returns, so
+// handlers and the middleware before Telemetry still see every error.
+func TelemetryErrorFilter() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.GetBool(recordedErrorsKey) && len(c.Errors) > 0 {
+			c.Set(hiddenErrorsKey, []*gin.Error(c.Errors))
+			c.Errors = nil
+		}
+	}
+}
+
+// traceIDSeedKey is the request context key of the trace ID taken from X-Trace-Id
+type traceIDSeedKey struct{}
+
+// seedTraceID records a valid inbound X-Trace-Id for the root span of the request
+// An inbound trace context (e.g., traceparent) or an active span takes precedence.
+func seedTraceID(c *gin.Context) {
+	ctx := c.Request.Context()
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	parent := otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(c.Request.Header))
+	if trace.SpanContextFromContext(parent).IsValid() {
+		return
+	}
+
+	if traceID, ok := parseTraceID(c.GetHeader(TraceIDHeader)); ok {
+		c.Request = c.Request.WithContext(context.WithValue(ctx, traceIDSeedKey{}, traceID))
+	}
+}
+
+// TraceIDGenerator returns the span ID generator of the platform tracer provider
+// Root spans of requests with a valid X-Trace-Id (see Telemetry) keep that trace ID; other IDs
+// are random.
+func TraceIDGenerator() sdktrace.IDGenerator {
+	return traceIDGenerator{}
+}
+
+// traceIDGenerator implements sdktrace.IDGenerator
+type traceIDGenerator struct{}
+
+// NewIDs returns the IDs of a root span
+func (traceIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if traceID, ok := ctx.Value(traceIDSeedKey{}).(trace.TraceID); ok {
+		return traceID, newSpanID()
+	}
+	return newTraceID(), newSpanID()
+}
+
+// NewSpanID returns the ID of a child span
+func (traceIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return newSpanID()
 }
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetrySeedsTraceIDFromHeader(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans), sdktrace.WithIDGenerator(TraceIDGenerator()))
+	previousTP, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousTP)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Telemetry("test"), TraceID())
+	engine.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	const seeded = "4bf92f3577b34da6a3ce929d0e0e4736"
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string // "" for a random trace ID
+	}{
+		{"x-trace-id", map[string]string{TraceIDHeader: seeded}, seeded},
+		{"uuid", map[string]string{TraceIDHeader: "4bf92f35-77b3-4da6-a3ce-929d0e0e4736"}, seeded},
+		{"traceparent wins", map[string]string{
+			TraceIDHeader:     seeded,
+			TraceParentHeader: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		}, "0af7651916cd43dd8448eb211c80319c"},
+		{"malformed", map[string]string{TraceIDHeader: "not-a-trace-id"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			ended := spans.Ended()
+			span := ended[len(ended)-1]
+			got := span.SpanContext().TraceID().String()
+			if tt.want != "" && got != tt.want {
+				t.Errorf("span trace ID = %s, want %s", got, tt.want)
+			}
+			if tt.want == "" && got == seeded {
+				t.Errorf("span trace ID = %s, want a random one", got)
+			}
+			if header := rec.Header().Get(TraceIDHeader); header != got {
+				t.Errorf("%s = %s, want the span trace ID %s", TraceIDHeader, header, got)
+			}
+		})
+	}
+}
+
+func TestTelemetryErrorFilterKeepsErrors(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previousTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(previousTP) })
+
+	var outerErrors int
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		outerErrors = len(c.Errors)
+	})
+	engine.Use(Telemetry("test"), TelemetryErrorFilter(), ErrorHandler(&testLogger{}))
+	engine.GET("/", func(c *gin.Context) {
+		_ = c.Error(platformErrors.NewNotFoundError("Order not found"))
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if outerErrors != 1 {
+		t.Errorf("outer middleware saw %d errors, want 1", outerErrors)
+	}
+	ended := spans.Ended()
+	span := ended[len(ended)-1]
+	if events := span.Events(); len(events) != 1 {
+		t.Errorf("recorded %d exception events, want 1 (ErrorHandler only)", len(events))
+	}
+	if span.Status().Code == codes.Error {
+		t.Errorf("span status = %v, want a 404 left unset", span.Status())
+	}
+}
@@ -0,0 +1,402 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errTimeoutHijack is returned when a handler running under WithTimeout tries to hijack the connection
+var errTimeoutHijack = errors.New("timeout middleware: connection hijacking is not supported")
+
+// handlerPanic carries a panic recovered from the timeout goroutine back to the request goroutine
+// ErrorHandler unwraps it so the original value and the stack of the handler goroutine are logged.
+type handlerPanic struct {
+	value any
+	stack []byte
+}
+
+// WithTimeout wraps the remaining handlers with a timeout using context.WithTimeout
+// If the handlers don't complete within the timeout, the client receives 408 Request Timeout.
+//
+// The remaining handlers run in a separate goroutine and write into a buffered response writer:
+//   - If they finish in time, the buffered response is copied to the client unchanged
+//   - If the timeout fires first, a single 408 response is written and flushed immediately,
+//     and anything the handlers write afterwards is discarded
+//   - A panic in the handlers is re-raised on the request goroutine so ErrorHandler recovers it
+//
+// The middleware waits for the handlers to return before returning itself, so the gin context
+// is never shared with a goroutine that outlives the request. Handlers should therefore honor
+// c.Request.Context() cancellation to release resources promptly after a timeout.
+//
+// Timeouts are logged with the logger of ErrorHandler (see GetLogger), together with the work
+// the handlers had done (buffered status and bytes, errors, and how long they kept running
+// after the deadline). Without ErrorHandler, nothing is logged.
+//
+// Example:
+//
+//	// Set 5 second timeout for this specific endpoint
+//	router.GET("/slow-endpoint", middleware.WithTimeout(5*time.Second), handler)
+//
+// Note: This is useful for specific endpoints that need stricter timeouts than the global server timeout.
+// Streaming responses (Flush) and connection hijacking are not supported under this middleware.
+func WithTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := GetLogger(c)
+
+		// Create context with timeout
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		// Replace request context and buffer the response
+		c.Request = c.Request.WithContext(ctx)
+		original := c.Writer
+		tw := newTimeoutWriter(original)
+		c.Writer = tw
+
+		// Negotiate the format of a timeout response up front: the gin context belongs to the handlers
+		response := newTimeoutResponse(c)
+
+		// Capture request fields up front: while the handlers run, the gin context belongs to them
+		baseFields := Fields{
+			"method":  c.Request.Method,
+			"path":    c.Request.URL.Path,
+			"timeout": timeout.String(),
+		}
+		addTraceLogFields(c, baseFields)
+
+		start := time.Now()
+		done := make(chan struct{})
+		panicChan := make(chan *handlerPanic, 1)
+		var finished time.Time // Set before done is closed
+
+		go func() {
+			defer func() {
+				finished = time.Now()
+				close(done)
+			}()
+			defer func() {
+				if p := recover(); p != nil {
+					panicChan <- &handlerPanic{value: p, stack: debug.Stack()}
+				}
+			}()
+			c.Next()
+		}()
+
+		// The handler response is kept only if the handlers finished before the deadline, so a
+		// handler answering after observing the cancellation cannot race the timeout response
+		timedOut, running := false, false
+		select {
+		case <-done:
+			timedOut = finishedLate(ctx, finished)
+		case <-ctx.Done():
+			select {
+			case <-done:
+				timedOut = finishedLate(ctx, finished)
+			default:
+				timedOut, running = true, true
+			}
+		}
+		if timedOut {
+			tw.timeout(ctx.Err(), response)
+			if running {
+				logTimeout(ctx, logger, baseFields, tw, start)
+			}
+		}
+
+		// Wait for the handlers so the gin context is not shared after this middleware returns
+		<-done
+		c.Writer = original
+
+		var recovered *handlerPanic
+		select {
+		case recovered = <-panicChan:
+		default:
+		}
+
+		if timedOut {
+			logTimedOutHandlerFinished(ctx, logger, baseFields, c, tw, start, recovered)
+			// Record the timeout first so ErrorHandler reports it rather than late write errors
+			// The response has already been written, so ErrorHandler only logs it
+			timeoutErr := &gin.Error{Err: ctx.Err(), Type: gin.ErrorTypePrivate}
+			c.Errors = append([]*gin.Error{timeoutErr}, c.Errors...)
+			c.Abort()
+		} else {
+			tw.flushTo(original)
+		}
+
+		if recovered != nil {
+			panic(recovered)
+		}
+	}
+}
+
+// finishedLate reports whether the handlers finished at or after the deadline of ctx
+func finishedLate(ctx context.Context, finished time.Time) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && errors.Is(ctx.Err(), context.DeadlineExceeded) && !finished.Before(deadline)
+}
+
+// logTimeout reports the state of the handlers at the moment the deadline was exceeded
+func logTimeout(ctx context.Context, logger Logger, baseFields Fields, tw *timeoutWriter, start time.Time) {
+	if logger == nil {
+		return
+	}
+
+	status, size := tw.snapshot()
+	fields := make(Fields, len(baseFields)+3)
+	for k, v := range baseFields {
+		fields[k] = v
+	}
+	fields["elapsed_ms"] = time.Since(start).Milliseconds()
+	fields["buffered_status"] = status
+	fields["buffered_bytes"] = size
+
+	logger.Warn(ctx, "Request timed out, handler still running", fields)
+}
+
+// logTimedOutHandlerFinished reports the work a timed-out handler did before it returned
+// Called after the handler goroutine has finished, so the gin context can be read safely.
+func logTimedOutHandlerFinished(ctx context.Context, logger Logger, baseFields Fields, c *gin.Context, tw *timeoutWriter, start time.Time, recovered *handlerPanic) {
+	if logger == nil {
+		return
+	}
+
+	status, size := tw.snapshot()
+	fields := make(Fields, len(baseFields)+5)
+	for k, v := range baseFields {
+		fields[k] = v
+	}
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	fields["discarded_status"] = status
+	fields["discarded_bytes"] = size
+	fields["handler_panicked"] = recovered != nil
+	if len(c.Errors) > 0 {
+		fields["errors"] = c.Errors.String()
+	}
+
+	logger.Warn(ctx, "Timed-out handler finished, response discarded", fields)
+}
+
+// timeoutWriter buffers the response of handlers running under WithTimeout
+// All methods are safe for concurrent use by the handler goroutine and the request goroutine.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	original gin.ResponseWriter
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	size     int
+	written  bool // WriteHeaderNow or Write has been called
+	timedOut bool
+}
+
+// newTimeoutWriter creates a buffered writer starting from the headers already set on original
+func newTimeoutWriter(original gin.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{
+		original: original,
+		header:   original.Header().Clone(),
+		status:   http.StatusOK,
+		size:     -1,
+	}
+}
+
+// Header returns the buffered header map
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader records the status code (gin semantics: not sent until the body is written)
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if code > 0 && !w.written && !w.timedOut {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow marks the header as written
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.written {
+		w.written = true
+		w.size = 0
+	}
+}
+
+// Write buffers data; after a timeout it returns http.ErrHandlerTimeout
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.written {
+		w.written = true
+		w.size = 0
+	}
+	if w.timedOut {
+		w.size += len(data)
+		return 0, http.ErrHandlerTimeout
+	}
+
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString buffers a string
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Status returns the buffered status code
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// Size returns the number of buffered bytes, or -1 if nothing was written
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.size
+}
+
+// Written reports whether the handlers started writing the response
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written
+}
+
+// Flush is a no-op: the response is only sent once the handlers finish
+func (w *timeoutWriter) Flush() {}
+
+// Hijack is not supported because the response is buffered
+func (w *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errTimeoutHijack
+}
+
+// CloseNotify delegates to the original writer
+func (w *timeoutWriter) CloseNotify() <-chan bool {
+	return w.original.CloseNotify()
+}
+
+// Pusher is not supported because the response is buffered
+func (w *timeoutWriter) Pusher() http.Pusher {
+	return nil
+}
+
+// snapshot returns the buffered status and size
+func (w *timeoutWriter) snapshot() (int, int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status, w.size
+}
+
+// timeoutResponse renders timeout responses in the error format of ErrorHandler
+type timeoutResponse struct {
+	renderer Renderer
+
+	// problem converts the error to problem details, nil unless ErrorHandler uses WithProblemDetails
+	problem func(apiErr *ApiError, errorType string) *ProblemDetails
+}
+
+// newTimeoutResponse negotiates the format of a timeout response for the request
+func newTimeoutResponse(c *gin.Context) timeoutResponse {
+	var cfg *errorHandlerConfig
+	if value, ok := c.Get(errorHandlerKey); ok {
+		cfg, _ = value.(*errorHandlerConfig)
+	}
+	problem := cfg != nil && cfg.problemDetails
+
+	accept := c.GetHeader("Accept")
+	if problem {
+		accept = problemAccept(accept)
+	}
+	registry := RequestRenderers(c)
+	renderer, ok := registry.Negotiate(accept)
+	if !ok {
+		renderer = registry.Default()
+	}
+	if !problem {
+		return timeoutResponse{renderer: renderer}
+	}
+
+	instance, traceID, typeBaseURI := c.Request.URL.Path, GetTraceID(c), cfg.problemTypeBaseURI
+	return timeoutResponse{
+		renderer: problemRenderer{renderer},
+		problem: func(apiErr *ApiError, errorType string) *ProblemDetails {
+			return problemDetails(instance, traceID, apiErr, errorType, typeBaseURI)
+		},
+	}
+}
+
+// timeout writes a single 408 response to the original writer and discards further writes
+// It never touches the gin context, which is still owned by the handler goroutine.
+func (w *timeoutWriter) timeout(err error, response timeoutResponse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.timedOut = true
+
+	apiErr, errorType := NewApiError("Request timeout exceeded", http.StatusRequestTimeout), "RequestTimeout"
+	if !errors.Is(err, context.DeadlineExceeded) {
+		apiErr, errorType = NewApiError("Request was cancelled by client", 499), "RequestCanceled"
+	}
+
+	var value any = apiErr
+	if response.problem != nil {
+		value = response.problem(apiErr, errorType)
+	}
+
+	renderer := response.renderer
+	var body bytes.Buffer
+	if renderErr := renderer.Render(&body, value); renderErr != nil {
+		renderer = jsonRenderer
+		body.Reset()
+		body.WriteString(fmt.Sprintf(`{"message":%q,"status":%d}`, apiErr.Message, apiErr.Status))
+	}
+
+	w.original.Header().Set("Content-Type", renderer.ContentType())
+	w.original.WriteHeader(apiErr.Status)
+	_, _ = w.original.Write(body.Bytes())
+	w.original.Flush()
+}
+
+// flushTo copies the buffered response to the original writer
+// Only called after the handlers returned, so no locking against them is needed.
+func (w *timeoutWriter) flushTo(original gin.ResponseWriter) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dst := original.Header()
+	for key := range dst {
+		if _, ok := w.header[key]; !ok {
+			dst.Del(key)
+		}
+	}
+	for key, values := range w.header {
+		dst[key] = values
+	}
+
+	original.WriteHeader(w.status)
+	if w.written {
+		original.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = original.Write(w.body.Bytes())
+	}
+}
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testLogEntry is a message recorded by testLogger
+type testLogEntry struct {
+	level  string
+	msg    string
+	fields Fields
+}
+
+// testLogger records log messages; it is safe for concurrent use
+type testLogger struct {
+	mu      sync.Mutex
+	entries []testLogEntry
+}
+
+func (l *testLogger) log(level, msg string, fields Fields) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, testLogEntry{level: level, msg: msg, fields: fields})
+}
+
+func (l *testLogger) Info(_ context.Context, msg string, fields Fields)  { l.log("info", msg, fields) }
+func (l *testLogger) Error(_ context.Context, msg string, fields Fields) { l.log("error", msg, fields) }
+func (l *testLogger) Warn(_ context.Context, msg string, fields Fields)  { l.log("warn", msg, fields) }
+func (l *testLogger) Debug(_ context.Context, msg string, fields Fields) { l.log("debug", msg, fields) }
+func (l *testLogger) Close() error                                       { return nil }
+
+// find returns the entries with the given message
+func (l *testLogger) find(msg string) []testLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []testLogEntry
+	for _, entry := range l.entries {
+		if entry.msg == msg {
+			found = append(found, entry)
+		}
+	}
+	return found
+}
+
+// headerCountingRecorder counts the status lines written to the client
+type headerCountingRecorder struct {
+	*httptest.ResponseRecorder
+	mu           sync.Mutex
+	writeHeaders int
+}
+
+func (r *headerCountingRecorder) WriteHeader(code int) {
+	r.mu.Lock()
+	r.writeHeaders++
+	r.mu.Unlock()
+	r.ResponseRecorder.WriteHeader(code)
+}
+
+func newTimeoutEngine(logger Logger, timeout time.Duration, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ErrorHandler(logger))
+	engine.GET("/", WithTimeout(timeout), handler)
+	return engine
+}
+
+func serve(engine *gin.Engine) *headerCountingRecorder {
+	rec := &headerCountingRecorder{ResponseRecorder: httptest.NewRecorder()}
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestWithTimeoutHandlerFinishesInTime(t *testing.T) {
+	logger := &testLogger{}
+	engine := newTimeoutEngine(logger, time.Second, func(c *gin.Context) {
+		c.Header("X-Handler", "done")
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	rec := serve(engine)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if got := rec.Header().Get("X-Handler"); got != "done" {
+		t.Errorf("X-Handler = %q, want %q", got, "done")
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"id":1}` {
+		t.Errorf("body = %s, want {\"id\":1}", got)
+	}
+	if rec.writeHeaders != 1 {
+		t.Errorf("status written %d times, want 1", rec.writeHeaders)
+	}
+	if entries := logger.find("Request timed out, handler still running"); len(entries) != 0 {
+		t.Errorf("unexpected timeout log: %v", entries)
+	}
+}
+
+func TestWithTimeoutWritesSingleTimeoutResponse(t *testing.T) {
+	logger := &testLogger{}
+	lateWrite := make(chan error, 1)
+	engine := newTimeoutEngine(logger, 20*time.Millisecond, func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		time.Sleep(20 * time.Millisecond)
+		c.Header("X-Late", "1")
+		c.Status(http.StatusOK)
+		_, err := c.Writer.WriteString("late response")
+		lateWrite <- err
+	})
+
+	rec := serve(engine)
+
+	if rec.Code != http.StatusRequestTimeout {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusRequestTimeout)
+	}
+	if rec.writeHeaders != 1 {
+		t.Errorf("status written %d times, want 1", rec.writeHeaders)
+	}
+	if strings.Contains(rec.Body.String(), "late response") || rec.Header().Get("X-Late") != "" {
+		t.Errorf("late write reached the client: headers %v, body %s", rec.Header(), rec.Body.String())
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%s)", err, rec.Body.String())
+	}
+	if body["message"] != "Request timeout exceeded" {
+		t.Errorf("message = %v, want %q", body["message"], "Request timeout exceeded")
+	}
+
+	if err := <-lateWrite; !errors.Is(err, http.ErrHandlerTimeout) {
+		t.Errorf("late write error = %v, want %v", err, http.ErrHandlerTimeout)
+	}
+	if entries := logger.find("Request timed out, handler still running"); len(entries) != 1 {
+		t.Errorf("timeout logged %d times, want 1", len(entries))
+	}
+	finished := logger.find("Timed-out handler finished, response discarded")
+	if len(finished) != 1 {
+		t.Fatalf("handler completion logged %d times, want 1", len(finished))
+	}
+	if got := finished[0].fields["discarded_bytes"]; got != len("late response") {
+		t.Errorf("discarded_bytes = %v, want %d", got, len("late response"))
+	}
+}
+
+func TestWithTimeoutPanicBeforeDeadline(t *testing.T) {
+	logger := &testLogger{}
+	engine := newTimeoutEngine(logger, time.Second, func(c *gin.Context) {
+		panic("boom")
+	})
+
+	rec := serve(engine)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if rec.writeHeaders != 1 {
+		t.Errorf("status written %d times, want 1", rec.writeHeaders)
+	}
+	entries := logger.find("Panic recovered (non-error type)")
+	if len(entries) != 1 {
+		t.Fatalf("panic logged %d times, want 1", len(entries))
+	}
+	if got := entries[0].fields["panic"]; got != "boom" {
+		t.Errorf("panic = %v, want %q", got, "boom")
+	}
+	// The stack is the one of the handler goroutine, not the re-raise
+	if stack, _ := entries[0].fields["stack_trace"].(string); !strings.Contains(stack, "TestWithTimeoutPanicBeforeDeadline") {
+		t.Errorf("stack trace does not include the handler:\n%s", stack)
+	}
+}
+
+func TestWithTimeoutPanicAfterDeadline(t *testing.T) {
+	logger := &testLogger{}
+	engine := newTimeoutEngine(logger, 20*time.Millisecond, func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		panic(errors.New("late boom"))
+	})
+
+	rec := serve(engine)
+
+	if rec.Code != http.StatusRequestTimeout {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusRequestTimeout)
+	}
+	if rec.writeHeaders != 1 {
+		t.Errorf("status written %d times, want 1", rec.writeHeaders)
+	}
+	entries := logger.find("Panic recovered")
+	if len(entries) != 1 {
+		t.Fatalf("panic logged %d times, want 1", len(entries))
+	}
+	if got := entries[0].fields["panic"]; got != "late boom" {
+		t.Errorf("panic = %v, want %q", got, "late boom")
+	}
+	finished := logger.find("Timed-out handler finished, response discarded")
+	if len(finished) != 1 || finished[0].fields["handler_panicked"] != true {
+		t.Errorf("handler completion log = %v, want handler_panicked", finished)
+	}
+}
+
+func TestWithTimeoutConcurrentRequests(t *testing.T) {
+	logger := &testLogger{}
+	engine := newTimeoutEngine(logger, 30*time.Millisecond, func(c *gin.Context) {
+		if c.Query("slow") != "" {
+			<-c.Request.Context().Done()
+			c.String(http.StatusOK, "late")
+			return
+		}
+		c.String(http.StatusOK, "fast")
+	})
+
+	const requests = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := range requests {
+		slow := i%2 == 0
+		wg.Go(func() {
+			target := "/"
+			if slow {
+				target = "/?slow=1"
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+			switch {
+			case slow && rec.Code != http.StatusRequestTimeout:
+				errs <- errors.New("slow request: status " + http.StatusText(rec.Code))
+			case !slow && (rec.Code != http.StatusOK || rec.Body.String() != "fast"):
+				errs <- errors.New("fast request: " + http.StatusText(rec.Code) + " " + rec.Body.String())
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if entries := logger.find("Timed-out handler finished, response discarded"); len(entries) != requests/2 {
+		t.Errorf("timeouts logged %d times, want %d", len(entries), requests/2)
+	}
+}
+
+func TestWithTimeoutUsesProblemDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ErrorHandler(&testLogger{}, WithProblemDetails("https://errors.example.com")))
+	engine.GET("/slow", WithTimeout(20*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if rec.Code != http.StatusRequestTimeout {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusRequestTimeout)
+	}
+	if got := rec.Header().Get("Content-Type"); got != MIMEProblemJSON {
+		t.Errorf("Content-Type = %q, want %q", got, MIMEProblemJSON)
+	}
+	var problem ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body is not JSON: %v (%s)", err, rec.Body.String())
+	}
+	want := ProblemDetails{
+		Type:     "https://errors.example.com/request-timeout",
+		Title:    http.StatusText(http.StatusRequestTimeout),
+		Status:   http.StatusRequestTimeout,
+		Detail:   "Request timeout exceeded",
+		Instance: "/slow",
+	}
+	if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+		problem.Detail != want.Detail || problem.Instance != want.Instance {
+		t.Errorf("problem = %+v, want %+v", problem, want)
+	}
+}
@@ -1,37 +1,128 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	// TraceIDHeader is the HTTP header name for trace ID
 	TraceIDHeader = "X-Trace-Id"
 
+	// TraceParentHeader is the W3C Trace Context header carrying trace ID, span ID and flags
+	TraceParentHeader = "traceparent"
+
+	// TraceStateHeader is the W3C Trace Context header carrying vendor-specific trace data
+	TraceStateHeader = "tracestate"
+
 	// TraceIDKey is the context key for storing trace ID
 	TraceIDKey = "trace_id"
+
+	// SpanIDKey is the context key for storing span ID
+	SpanIDKey = "span_id"
 )
 
-// TraceID generates or extracts a trace ID for each request
-// If the request already has a trace ID in the X-Trace-Id header, it will be used
-// Otherwise, a new UUID will be generated
-// The trace ID is stored in the gin context and added to the response header
+// TraceID resolves the W3C trace ID of each request (32 lowercase hex characters)
+// The trace ID is taken, in order, from:
+//   - the active OpenTelemetry span (when the Telemetry middleware runs before TraceID)
+//   - a valid inbound traceparent header
+//   - a valid inbound X-Trace-Id header (32 hex characters or a UUID, normalized)
+//   - a new random trace ID
+//
+// Inbound values that are malformed are ignored, so they never reach logs.
+// The trace ID is stored in the gin context and returned in the X-Trace-Id header, together
+// with traceparent and tracestate. Without an active span, the span ID identifies the request
+// but no span is recorded; its span context is added to the request context, so outbound calls
+// (see httpclient) still propagate the trace.
 func TraceID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		traceID := c.GetHeader(TraceIDHeader)
-
-		if traceID == "" {
-			traceID = uuid.New().String()
+		sc := requestSpanContext(c)
+		if !trace.SpanContextFromContext(c.Request.Context()).IsValid() {
+			c.Request = c.Request.WithContext(trace.ContextWithSpanContext(c.Request.Context(), sc))
 		}
 
+		traceID := sc.TraceID().String()
+		spanID := sc.SpanID().String()
 		c.Set(TraceIDKey, traceID)
+		c.Set(SpanIDKey, spanID)
+
 		c.Header(TraceIDHeader, traceID)
+		c.Header(TraceParentHeader, "00-"+traceID+"-"+spanID+"-"+sc.TraceFlags().String())
+		if state := sc.TraceState().String(); state != "" {
+			c.Header(TraceStateHeader, state)
+		}
 
 		c.Next()
 	}
 }
 
+// requestSpanContext returns the span context of the active span, or one derived from
+// the inbound headers with a new span ID
+func requestSpanContext(c *gin.Context) trace.SpanContext {
+	if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+		return sc
+	}
+
+	cfg := trace.SpanContextConfig{SpanID: newSpanID()}
+
+	// The propagator validates version, lengths, charset and non-zero IDs
+	parent := trace.SpanContextFromContext(
+		propagation.TraceContext{}.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header)),
+	)
+	if parent.IsValid() {
+		cfg.TraceID = parent.TraceID()
+		cfg.TraceFlags = parent.TraceFlags()
+		cfg.TraceState = parent.TraceState()
+	} else if traceID, ok := parseTraceID(c.GetHeader(TraceIDHeader)); ok {
+		cfg.TraceID = traceID
+	} else {
+		cfg.TraceID = newTraceID()
+	}
+
+	return trace.NewSpanContext(cfg)
+}
+
+// parseTraceID parses an X-Trace-Id value: 32 hex characters or a UUID (36 characters with dashes)
+func parseTraceID(value string) (trace.TraceID, bool) {
+	if len(value) == 36 && value[8] == '-' && value[13] == '-' && value[18] == '-' && value[23] == '-' {
+		value = strings.ReplaceAll(value, "-", "")
+	}
+	if len(value) != 32 {
+		return trace.TraceID{}, false
+	}
+
+	traceID, err := trace.TraceIDFromHex(strings.ToLower(value))
+	if err != nil {
+		return trace.TraceID{}, false
+	}
+	return traceID, true
+}
+
+// newTraceID generates a random non-zero trace ID
+func newTraceID() trace.TraceID {
+	var traceID trace.TraceID
+	for !traceID.IsValid() {
+		_, _ = rand.Read(traceID[:])
+	}
+	return traceID
+}
+
+// newSpanID generates a random non-zero span ID
+func newSpanID() trace.SpanID {
+	var spanID trace.SpanID
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
+
 // GetTraceID extracts the trace ID from the gin context
 // Returns empty string if no trace ID is found
 func GetTraceID(c *gin.Context) string {
@@ -42,3 +133,55 @@ func GetTraceID(c *gin.Context) string {
 	}
 	return ""
 }
+
+// GetSpanID extracts the span ID from the gin context
+// Returns empty string if no span ID is found
+func GetSpanID(c *gin.Context) string {
+	if spanID, exists := c.Get(SpanIDKey); exists {
+		if id, ok := spanID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// TraceLogFields returns the trace and span IDs of the request as log fields, in W3C hex
+// (trace_id, span_id) and in Datadog decimal format (dd.trace_id, dd.span_id)
+// Returns empty fields if TraceID did not run.
+func TraceLogFields(c *gin.Context) Fields {
+	fields := Fields{}
+	addTraceLogFields(c, fields)
+	return fields
+}
+
+// addTraceLogFields adds the trace log fields of the request to fields
+func addTraceLogFields(c *gin.Context, fields Fields) {
+	traceID := GetTraceID(c)
+	if traceID == "" {
+		return
+	}
+	fields["trace_id"] = traceID
+	if ddTraceID, ok := datadogID(traceID); ok {
+		fields["dd.trace_id"] = ddTraceID
+	}
+
+	if spanID := GetSpanID(c); spanID != "" {
+		fields["span_id"] = spanID
+		if ddSpanID, ok := datadogID(spanID); ok {
+			fields["dd.span_id"] = ddSpanID
+		}
+	}
+}
+
+// datadogID converts a hex trace or span ID to Datadog's decimal format
+// Datadog uses the lower 64 bits of 128-bit trace IDs.
+func datadogID(hexID string) (string, bool) {
+	if len(hexID) > 16 {
+		hexID = hexID[len(hexID)-16:]
+	}
+	b, err := hex.DecodeString(hexID)
+	if err != nil || len(b) != 8 {
+		return "", false
+	}
+	return strconv.FormatUint(binary.BigEndian.Uint64(b), 10), true
+}
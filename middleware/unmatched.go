@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// NotFoundHandler reports requests matching no route as NotFoundError, so ErrorHandler
+// answers them in the platform error format
+func NotFoundHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Error(platformErrors.NewNotFoundError(
+			fmt.Sprintf("No route matches %s %s", c.Request.Method, c.Request.URL.Path),
+		))
+		c.Abort()
+	}
+}
+
+// MethodNotAllowedHandler reports requests whose path exists with other methods as
+// MethodNotAllowedError, with an Allow header listing the registered methods
+// routes returns the registered routes (e.g., engine.Routes); it is read once, at the
+// first request with a wrong method, so register every route before serving.
+func MethodNotAllowedHandler(routes func() gin.RoutesInfo) gin.HandlerFunc {
+	var (
+		once  sync.Once
+		index methodTrees
+	)
+	return func(c *gin.Context) {
+		once.Do(func() { index = newMethodTrees(routes()) })
+		c.Error(platformErrors.NewMethodNotAllowedError(
+			fmt.Sprintf("Method %s is not allowed for %s", c.Request.Method, c.Request.URL.Path),
+			platformErrors.WithAllowHeader(index.allowed(c.Request.URL.Path)...),
+		))
+		c.Abort()
+	}
+}
+
+// AllowedMethods returns the methods of the routes matching a request path, sorted
+func AllowedMethods(routes gin.RoutesInfo, path string) []string {
+	return newMethodTrees(routes).allowed(path)
+}
+
+// methodTrees holds one route tree per method, looked up the way gin looks a path up
+type methodTrees map[string]*routeNode
+
+func newMethodTrees(routes gin.RoutesInfo) methodTrees {
+	trees := methodTrees{}
+	for _, route := range routes {
+		tree := trees[route.Method]
+		if tree == nil {
+			tree = &routeNode{}
+			trees[route.Method] = tree
+		}
+		tree.add(strings.Split(strings.TrimPrefix(route.Path, "/"), "/"))
+	}
+	return trees
+}
+
+// allowed returns the methods whose tree matches path, sorted
+func (t methodTrees) allowed(path string) []string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	var methods []string
+	for method, tree := range t {
+		if tree.match(segments) {
+			methods = append(methods, method)
+		}
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// routeNode is one path segment of a route tree
+// ":name" segments go to param and "*name" sets catchAll; match prefers static
+// children over the parameter and the parameter over the catch-all, falling back
+// like gin does when the preferred branch has no route.
+type routeNode struct {
+	static   map[string]*routeNode
+	param    *routeNode
+	catchAll bool
+	route    bool
+}
+
+func (n *routeNode) add(segments []string) {
+	for _, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, "*"):
+			n.catchAll = true
+			return
+		case strings.HasPrefix(segment, ":"):
+			if n.param == nil {
+				n.param = &routeNode{}
+			}
+			n = n.param
+		default:
+			if n.static == nil {
+				n.static = map[string]*routeNode{}
+			}
+			child := n.static[segment]
+			if child == nil {
+				child = &routeNode{}
+				n.static[segment] = child
+			}
+			n = child
+		}
+	}
+	n.route = true
+}
+
+func (n *routeNode) match(segments []string) bool {
+	if len(segments) == 0 {
+		return n.route
+	}
+	if child := n.static[segments[0]]; child != nil && child.match(segments[1:]) {
+		return true
+	}
+	if n.param != nil && segments[0] != "" && n.param.match(segments[1:]) {
+		return true
+	}
+	return n.catchAll
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAllowedMethods(t *testing.T) {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/users/new"},
+		{Method: http.MethodPost, Path: "/users/:id/edit"},
+		{Method: http.MethodDelete, Path: "/users/:id"},
+		{Method: http.MethodGet, Path: "/files/*filepath"},
+		{Method: http.MethodPut, Path: "/users/"},
+		{Method: http.MethodGet, Path: "/"},
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/users/new", []string{http.MethodDelete, http.MethodGet}},
+		{"/users/42", []string{http.MethodDelete}},
+		{"/users/new/edit", []string{http.MethodPost}},
+		{"/users/", []string{http.MethodPut}},
+		{"/users", nil},
+		{"/users/42/other", nil},
+		{"/files/", []string{http.MethodGet}},
+		{"/files/a/b.txt", []string{http.MethodGet}},
+		{"/files", nil},
+		{"/", []string{http.MethodGet}},
+	}
+	for _, tt := range tests {
+		if got := AllowedMethods(routes, tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("AllowedMethods(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMethodNotAllowedHandlerAllowHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.HandleMethodNotAllowed = true
+	engine.Use(ErrorHandler(&testLogger{}))
+	engine.NoRoute(NotFoundHandler())
+	engine.NoMethod(MethodNotAllowedHandler(engine.Routes))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/orders/:id", ok)
+	engine.PUT("/orders/:id", ok)
+	engine.POST("/orders/export", ok)
+
+	tests := []struct {
+		method, path string
+		status       int
+		allow        string
+	}{
+		{http.MethodPost, "/orders/42", http.StatusMethodNotAllowed, "GET, PUT"},
+		{http.MethodDelete, "/orders/export", http.StatusMethodNotAllowed, "GET, POST, PUT"},
+		{http.MethodGet, "/missing", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, rec.Code, tt.status)
+		}
+		if got := rec.Header().Get("Allow"); got != tt.allow {
+			t.Errorf("%s %s: Allow = %q, want %q", tt.method, tt.path, got, tt.allow)
+		}
+	}
+}
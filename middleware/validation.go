@@ -0,0 +1,310 @@
+package middleware
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	deTranslations "github.com/go-playground/validator/v10/translations/de"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+	frTranslations "github.com/go-playground/validator/v10/translations/fr"
+	itTranslations "github.com/go-playground/validator/v10/translations/it"
+	jaTranslations "github.com/go-playground/validator/v10/translations/ja"
+	nlTranslations "github.com/go-playground/validator/v10/translations/nl"
+	ptTranslations "github.com/go-playground/validator/v10/translations/pt"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	trTranslations "github.com/go-playground/validator/v10/translations/tr"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+// validationLocale is a locale with built-in validation messages
+type validationLocale struct {
+	locale   func() locales.Translator
+	register func(v *validator.Validate, trans ut.Translator) error
+}
+
+// validationLocales lists the locales with built-in validation messages
+var validationLocales = map[string]validationLocale{
+	"de":    {de.New, deTranslations.RegisterDefaultTranslations},
+	"en":    {en.New, enTranslations.RegisterDefaultTranslations},
+	"es":    {es.New, esTranslations.RegisterDefaultTranslations},
+	"fr":    {fr.New, frTranslations.RegisterDefaultTranslations},
+	"it":    {it.New, itTranslations.RegisterDefaultTranslations},
+	"ja":    {ja.New, jaTranslations.RegisterDefaultTranslations},
+	"nl":    {nl.New, nlTranslations.RegisterDefaultTranslations},
+	"pt":    {pt.New, ptTranslations.RegisterDefaultTranslations},
+	"pt_BR": {pt_BR.New, ptBRTranslations.RegisterDefaultTranslations},
+	"ru":    {ru.New, ruTranslations.RegisterDefaultTranslations},
+	"tr":    {tr.New, trTranslations.RegisterDefaultTranslations},
+	"zh":    {zh.New, zhTranslations.RegisterDefaultTranslations},
+}
+
+// SupportedValidationLocales returns the locales with built-in validation messages
+func SupportedValidationLocales() []string {
+	names := make([]string, 0, len(validationLocales))
+	for name := range validationLocales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateLocales checks that every locale has built-in validation messages
+func ValidateLocales(localeNames []string) error {
+	for _, name := range localeNames {
+		if _, ok := validationLocales[name]; !ok {
+			return fmt.Errorf("unsupported locale '%s' (supported: %s)", name, strings.Join(SupportedValidationLocales(), ", "))
+		}
+	}
+	return nil
+}
+
+// CustomValidator is an application validation tag with its messages
+type CustomValidator struct {
+	// Tag is the validation tag used in binding/validate struct tags (e.g., "sku")
+	Tag string
+
+	// Func validates a field
+	Func validator.Func
+
+	// Messages holds the message template per locale (e.g., {"en": "{0} must be a valid SKU"})
+	// {0} is replaced by the field name and {1} by the tag parameter. Locales without a
+	// message use the message of the first configured locale.
+	Messages map[string]string
+}
+
+// ValidationConfig configures validation error messages
+type ValidationConfig struct {
+	// Locales lists the locales of validation messages, selected from the Accept-Language header
+	// The first locale is used when none of the accepted languages is configured.
+	Locales []string
+
+	// StructFieldNames reports Go struct field names instead of json (or form, uri, header) tag names
+	StructFieldNames bool
+
+	// Messages overrides built-in message templates, per locale and tag
+	// (e.g., {"en": {"required": "{0} cannot be empty"}})
+	Messages map[string]map[string]string
+
+	// Validators registers application validation tags
+	Validators []CustomValidator
+}
+
+// validationTranslator holds the translators used by ErrorHandler, nil until ConfigureValidation is called
+var validationTranslator atomic.Pointer[ut.UniversalTranslator]
+
+// violationMessages renders the messages of OpenAPIValidation violations, nil until ConfigureValidation is called
+var violationMessages atomic.Pointer[violationTranslator]
+
+// violationTranslator is a validator with the built-in translations and message overrides of
+// gin's validator; its fields are named by their "name" tag, whatever StructFieldNames is
+type violationTranslator struct {
+	validate *validator.Validate
+	uni      *ut.UniversalTranslator
+}
+
+// ConfigureValidation sets up validation messages on gin's validator (binding.Validator)
+// It registers the translations of every locale, the field name function, message overrides
+// and custom validators. ErrorHandler then adds a translated message to each validation error,
+// OpenAPIValidation errors included, in the locale negotiated from the Accept-Language header.
+// The validator is global, so the configuration applies to every platform of the process.
+func ConfigureValidation(cfg ValidationConfig) error {
+	localeNames := cfg.Locales
+	if len(localeNames) == 0 {
+		localeNames = []string{"en"}
+	}
+	if err := ValidateLocales(localeNames); err != nil {
+		return err
+	}
+
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("binding.Validator engine is %T, not *validator.Validate", binding.Validator.Engine())
+	}
+
+	if !cfg.StructFieldNames {
+		v.RegisterTagNameFunc(tagFieldName)
+	}
+
+	uni, err := registerTranslations(v, localeNames, cfg.Messages)
+	if err != nil {
+		return err
+	}
+
+	violations := validator.New()
+	violations.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("name")
+	})
+	violationsUni, err := registerTranslations(violations, localeNames, cfg.Messages)
+	if err != nil {
+		return err
+	}
+
+	for _, custom := range cfg.Validators {
+		if err := v.RegisterValidation(custom.Tag, custom.Func); err != nil {
+			return fmt.Errorf("registering validator '%s': %w", custom.Tag, err)
+		}
+		for _, name := range localeNames {
+			message, ok := custom.Messages[name]
+			if !ok {
+				message, ok = custom.Messages[localeNames[0]]
+			}
+			if !ok {
+				continue
+			}
+			trans, _ := uni.GetTranslator(name)
+			if err := registerMessage(v, trans, custom.Tag, message); err != nil {
+				return err
+			}
+		}
+	}
+
+	validationTranslator.Store(uni)
+	violationMessages.Store(&violationTranslator{validate: violations, uni: violationsUni})
+	return nil
+}
+
+// registerTranslations registers the built-in translations of the locales and the message
+// overrides on a validator, and returns their translators
+func registerTranslations(v *validator.Validate, localeNames []string, messages map[string]map[string]string) (*ut.UniversalTranslator, error) {
+	translators := make([]locales.Translator, 0, len(localeNames))
+	for _, name := range localeNames {
+		translators = append(translators, validationLocales[name].locale())
+	}
+	uni := ut.New(translators[0], translators...)
+
+	for _, name := range localeNames {
+		trans, _ := uni.GetTranslator(name)
+		if err := validationLocales[name].register(v, trans); err != nil {
+			return nil, fmt.Errorf("registering %s translations: %w", name, err)
+		}
+	}
+
+	for name, overrides := range messages {
+		trans, found := uni.GetTranslator(name)
+		if !found {
+			return nil, fmt.Errorf("messages for locale '%s', which is not configured", name)
+		}
+		for tag, message := range overrides {
+			if err := registerMessage(v, trans, tag, message); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return uni, nil
+}
+
+// registerMessage registers a message template for a tag ({0} field, {1} parameter)
+func registerMessage(v *validator.Validate, trans ut.Translator, tag, message string) error {
+	err := v.RegisterTranslation(tag, trans,
+		func(trans ut.Translator) error {
+			return trans.Add(tag, message, true)
+		},
+		func(trans ut.Translator, fe validator.FieldError) string {
+			msg, err := trans.T(tag, fe.Field(), fe.Param())
+			if err != nil {
+				return fe.Error()
+			}
+			return msg
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("registering %s message for '%s': %w", trans.Locale(), tag, err)
+	}
+	return nil
+}
+
+// tagFieldName names struct fields after their json tag, or form, uri and header tags
+// for fields bound from other request sources
+func tagFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri", "header"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// validationTranslatorFor returns the translator of the request locale, nil when
+// ConfigureValidation was not called
+func validationTranslatorFor(ctx *gin.Context) ut.Translator {
+	return requestTranslator(ctx, validationTranslator.Load())
+}
+
+// requestTranslator returns the translator of uni for the request locale, nil when uni is nil
+func requestTranslator(ctx *gin.Context, uni *ut.UniversalTranslator) ut.Translator {
+	if uni == nil {
+		return nil
+	}
+	trans, _ := uni.FindTranslator(acceptedLanguages(ctx.GetHeader("Accept-Language"))...)
+	return trans
+}
+
+// acceptedLanguages parses an Accept-Language header into locale names, ordered by preference
+// Regional tags are followed by their base language (e.g., "pt-PT" gives "pt_PT", "pt").
+func acceptedLanguages(header string) []string {
+	type language struct {
+		tag string
+		q   float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 && parsed <= 1 {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			languages = append(languages, language{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].q > languages[j].q
+	})
+
+	var names []string
+	for _, lang := range languages {
+		name := strings.ReplaceAll(lang.tag, "-", "_")
+		names = append(names, name)
+		if base, _, regional := strings.Cut(name, "_"); regional && !slices.Contains(names, base) {
+			names = append(names, base)
+		}
+	}
+	return names
+}
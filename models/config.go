@@ -1,10 +1,16 @@
 package config
 
 import (
+	"fmt"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/internal/telemetry"
 	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
 // Config holds all configuration for the HTTP platform
@@ -34,17 +40,23 @@ type Config struct {
 	// CORS configuration
 	// Note: When AllowedOrigins is ["*"], AllowCredentials MUST be false (CORS spec requirement)
 	// To use credentials, specify explicit origins like ["https://example.com", "https://app.example.com"]
-	AllowedOrigins   []string // Origins allowed to access the API (e.g., ["*"], ["https://example.com"])
-	AllowedMethods   []string // HTTP methods allowed (e.g., ["GET", "POST"])
-	AllowedHeaders   []string // Request headers allowed (e.g., ["Content-Type", "Authorization"])
-	ExposedHeaders   []string // Response headers exposed to the client (e.g., ["X-Trace-Id"])
-	AllowCredentials bool     // Allow cookies and HTTP auth (incompatible with wildcard origin)
+	// Besides exact origins, AllowedOrigins accepts wildcard subdomains ("https://*.example.com")
+	// and regular expressions prefixed with "regex:" ("regex:https://(a|b)\.example\.com")
+	AllowedOrigins   []string      // Origins allowed to access the API (e.g., ["*"], ["https://example.com"])
+	AllowedMethods   []string      // HTTP methods allowed (e.g., ["GET", "POST"])
+	AllowedHeaders   []string      // Request headers allowed (e.g., ["Content-Type", "Authorization"])
+	ExposedHeaders   []string      // Response headers exposed to the client (e.g., ["X-Trace-Id"])
+	AllowCredentials bool          // Allow cookies and HTTP auth (incompatible with wildcard origin)
 	MaxAge           time.Duration // How long preflight results can be cached
 
+	// AllowOriginFunc is consulted when an origin does not match AllowedOrigins
+	// It receives the request context, e.g. to look up tenant-specific origins
+	AllowOriginFunc func(c *gin.Context, origin string) bool
+
 	// Middleware toggles
-	EnableTraceID            bool
-	EnableCORS               bool
-	EnableLogger             bool
+	EnableTraceID             bool
+	EnableCORS                bool
+	EnableLogger              bool
 	EnableContextCancellation bool // Detects and handles client disconnections early
 
 	// BasePath is the base path for all routes (e.g., "/api/v1")
@@ -53,6 +65,56 @@ type Config struct {
 	// TrustedProxies defines a list of trusted proxies
 	TrustedProxies []string
 
+	// RoutesEndpoint is the path of a debug endpoint serving the route table as JSON
+	// (e.g., "/debug/routes"). It is registered outside BasePath. Empty disables it.
+	RoutesEndpoint string
+
+	// OpenAPI documentation (registered outside BasePath, empty paths disable the endpoints)
+	OpenAPIPath     string // Path serving the generated OpenAPI 3.1 document (e.g., "/openapi.json")
+	OpenAPIDocsPath string // Path serving the documentation UI (e.g., "/docs"), requires OpenAPIPath
+	OpenAPIUI       string // Documentation UI: "swagger" (default) or "redoc"
+
+	// OpenAPIAssetsURL is the base URL of the UI scripts and styles (default: the jsDelivr CDN,
+	// see openapi.DefaultAssetsURL)
+	OpenAPIAssetsURL string
+
+	// OpenAPIAssets serves the UI scripts and styles under <OpenAPIDocsPath>/assets instead
+	// (e.g., uiassets.Handler(), which embeds them in the binary)
+	OpenAPIAssets http.Handler
+
+	// ErrorFormat selects the error response body written by ErrorHandler:
+	// "api_error" (default, {message, error, status, cause}) or "problem" (RFC 9457 problem details)
+	ErrorFormat string
+
+	// ProblemTypeBaseURI prefixes problem type URIs (e.g., "https://errors.example.com")
+	// Empty uses "about:blank" as the type of every problem.
+	ProblemTypeBaseURI string
+
+	// Handlers of unmatched requests, nil uses the defaults answering through ErrorHandler
+	NoRouteHandler  gin.HandlerFunc // No route matches the path (default: 404 NotFoundError)
+	NoMethodHandler gin.HandlerFunc // The path exists with other methods (default: 405 MethodNotAllowedError)
+
+	// AggregateErrors makes ErrorHandler respond with every error added with c.Error instead of the first one
+	AggregateErrors bool
+
+	// Validation messages (see middleware.ConfigureValidation)
+	// gin's validator is global, so it is only configured when one of these fields is set.
+	ValidationLocales          []string                     // Locales of validation messages, the first is the fallback (e.g., ["en"])
+	ValidationStructFieldNames bool                         // Report Go struct field names instead of json tag names
+	ValidationMessages         map[string]map[string]string // Message templates per locale and tag, overriding built-in ones
+	CustomValidators           []middleware.CustomValidator // Application validation tags with their messages
+
+	// ErrorMappers convert application errors (e.g., sql.ErrNoRows) to responses before the built-in handling
+	ErrorMappers []middleware.ErrorMapper
+
+	// Prometheus metrics (registered outside BasePath, an empty path disables metrics)
+	MetricsPath      string    // Path serving the metrics (e.g., "/metrics")
+	MetricsNamespace string    // Prefix of metric names (e.g., "orders")
+	MetricsBuckets   []float64 // Latency histogram buckets in seconds (default: prometheus.DefBuckets)
+
+	// Health checks (registered outside BasePath, an empty path disables the endpoint)
+	HealthPath string // Path serving the health report (e.g., "/health")
+
 	// Telemetry configuration (OpenTelemetry with Datadog)
 	EnableTelemetry    bool
 	ServiceName        string
@@ -60,37 +122,135 @@ type Config struct {
 	Environment        string
 	OTLPEndpoint       string // e.g., "192.168.1.100:4318" for Datadog Agent
 	TelemetrySampleAll bool   // If true, samples all traces. If false, uses default sampling
+
+	// Trace sampling; rules are evaluated first, then the parent decision, then the ratio
+	TelemetrySampleRatio   float64        // Ratio of sampled traces when TelemetrySampleAll is false (default: 0.1)
+	TelemetrySamplingRules []SamplingRule // Per-route ratios, first match wins (e.g., never sample /health)
+	TelemetryParentBased   bool           // Follow the sampling decision of the upstream traceparent (default: true)
+	TelemetrySampleErrors  bool           // Also export unsampled traces ending with an error (tail-style)
+
+	// OpenTelemetry metrics, exported to OTLPEndpoint when telemetry is enabled
+	EnableTelemetryMetrics   bool          // Export HTTP server and application metrics (default: false)
+	TelemetryMetricsInterval time.Duration // Export interval (default: 60s)
+
+	// OpenTelemetry logs, exported to OTLPEndpoint when telemetry is enabled
+	// Records are emitted by loggers bridging to OTel logs (see middleware.OTelLogger).
+	EnableTelemetryLogs bool // Export OTel log records (default: false)
+
+	// Request attributes added to the request span
+	TelemetrySpanHeaders     []string                  // Request headers recorded as http.request.header.<name> (avoid credentials)
+	TelemetrySpanQueryParams []string                  // Query parameters recorded as http.request.query.<name>
+	TelemetryUserID          func(*gin.Context) string // User of the request, recorded as enduser.id
+	TelemetryTenantID        func(*gin.Context) string // Tenant of the request, recorded as tenant.id
+
+	// Resource attributes describing the service on every span, metric and log
+	// TelemetryResourceAttributes take precedence over detected attributes; they cannot set
+	// service.name, service.version or deployment.environment (see ServiceName, ServiceVersion, Environment).
+	TelemetryResourceDetectors  []string          // "host", "process", "container" and/or "kubernetes" (default: none)
+	TelemetryResourceAttributes map[string]string // Additional attributes (e.g., {"team": "payments", "region": "eu-west-1"})
+
+	// Telemetry exporter
+	// Settings left empty fall back to the standard OTEL_EXPORTER_OTLP_* environment variables,
+	// and OTEL_EXPORTER_OTLP_ENDPOINT (or the per-signal variables) takes precedence over OTLPEndpoint,
+	// OTLPInsecure and OTLPURLPath: the URL scheme then selects TLS and the URL path is used as is.
+	TelemetryExporter string            // "otlp" (default), "stdout" or "file" (local debugging)
+	TelemetryFilePath string            // Output file of the "file" exporter (JSON lines)
+	OTLPProtocol      string            // "http/protobuf" (default) or "grpc" (use port 4317)
+	OTLPInsecure      bool              // Plaintext connection to a local agent (default: true)
+	OTLPCAFile        string            // CA certificate verifying the collector (enables TLS)
+	OTLPCertFile      string            // Client certificate for mTLS
+	OTLPKeyFile       string            // Client key for mTLS
+	OTLPHeaders       map[string]string // Headers sent with every export (e.g., {"DD-API-KEY": "..."}), require TLS to a remote endpoint
+	OTLPCompression   string            // "gzip" or "none" (default: none)
+	OTLPURLPath       string            // Prefix of the HTTP signal paths (e.g., "/otlp" gives /otlp/v1/traces)
+	OTLPTimeout       time.Duration     // Timeout of each export (default: 10s)
+	OTLPRetry         *OTLPRetryConfig  // Retry of failed exports (default: 5s initial, 30s max interval, 1m total)
+
+	// Telemetry initialization failures (invalid TLS files, unwritable export file, ...)
+	TelemetryInitMode       string        // "lenient" (default: log and run without telemetry), "strict" (New fails) or "retry"
+	TelemetryInitBackoff    time.Duration // First delay between attempts in "retry" mode, doubled after each failure (default: 1s)
+	TelemetryInitMaxBackoff time.Duration // Maximum delay between attempts in "retry" mode (default: 1m)
 }
 
+// SamplingRule sets the sampling ratio of a route (see WithTelemetrySamplingRules)
+type SamplingRule = telemetry.SamplingRule
+
+// OTLPRetryConfig configures the retry of failed OTLP exports (see WithOTLPRetry)
+type OTLPRetryConfig = telemetry.RetryConfig
+
+// Telemetry exporters (see Config.TelemetryExporter)
+const (
+	TelemetryExporterOTLP   = telemetry.ExporterOTLP
+	TelemetryExporterStdout = telemetry.ExporterStdout
+	TelemetryExporterFile   = telemetry.ExporterFile
+)
+
+// Telemetry resource detectors (see Config.TelemetryResourceDetectors)
+const (
+	ResourceDetectorHost       = telemetry.DetectorHost       // host.name, os.type, os.description
+	ResourceDetectorProcess    = telemetry.DetectorProcess    // process.pid, process.executable.name, process.runtime.*
+	ResourceDetectorContainer  = telemetry.DetectorContainer  // container.id, read from the cgroup of the process
+	ResourceDetectorKubernetes = telemetry.DetectorKubernetes // k8s.pod.*, k8s.namespace.name, k8s.node.name from downward API env vars
+)
+
+// Telemetry initialization modes (see Config.TelemetryInitMode)
+// Strict and retry modes send an empty export request (no span) through the OTLP trace exporter
+// when initializing, so an unreachable collector fails initialization. Retry mode only retries probe failures:
+// configuration errors (e.g., an unreadable CA file) would fail every attempt and are not retried.
+const (
+	TelemetryInitLenient = "lenient"
+	TelemetryInitStrict  = "strict"
+	TelemetryInitRetry   = "retry"
+)
+
+// OTLP protocols (see Config.OTLPProtocol)
+const (
+	OTLPProtocolHTTP = telemetry.ProtocolHTTP
+	OTLPProtocolGRPC = telemetry.ProtocolGRPC
+)
+
 type Option func(*Config)
 
+// Error response formats (see Config.ErrorFormat)
+const (
+	ErrorFormatAPIError = "api_error"
+	ErrorFormatProblem  = "problem"
+)
+
 func DefaultConfig() Config {
 	return Config{
-		Port:               8080,
-		Mode:               "debug",
-		ReadTimeout:        30 * time.Second,
-		WriteTimeout:       30 * time.Second,
-		IdleTimeout:        60 * time.Second,
-		MaxHeaderBytes:     1 << 20, // 1 MB
-		Logger:             nil,     // Must be set by user
-		AllowedOrigins:     []string{"*"},
-		AllowedMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
-		AllowedHeaders:     []string{"*"},
-		ExposedHeaders:     []string{"Content-Length", "X-Trace-Id"},
-		AllowCredentials:   false, // Must be false when using wildcard origin "*"
-		MaxAge:                   12 * time.Hour,
-		EnableTraceID:            true,
-		EnableCORS:               true,
-		EnableLogger:             true,
+		Port:                      8080,
+		Mode:                      "debug",
+		ReadTimeout:               30 * time.Second,
+		WriteTimeout:              30 * time.Second,
+		IdleTimeout:               60 * time.Second,
+		MaxHeaderBytes:            1 << 20, // 1 MB
+		Logger:                    nil,     // Must be set by user
+		AllowedOrigins:            []string{"*"},
+		AllowedMethods:            []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
+		AllowedHeaders:            []string{"*"},
+		ExposedHeaders:            []string{"Content-Length", "X-Trace-Id", "traceparent", "tracestate", "Retry-After"},
+		AllowCredentials:          false, // Must be false when using wildcard origin "*"
+		MaxAge:                    12 * time.Hour,
+		EnableTraceID:             true,
+		EnableCORS:                true,
+		EnableLogger:              true,
 		EnableContextCancellation: true, // Recommended to avoid wasting resources on cancelled requests
 		BasePath:                  "",
-		TrustedProxies:     nil,
-		EnableTelemetry:    false,
-		ServiceName:        "http-platform-service",
-		ServiceVersion:     "1.0.0",
-		Environment:        "development",
-		OTLPEndpoint:       "localhost:4318",
-		TelemetrySampleAll: true,
+		TrustedProxies:            nil,
+		ErrorFormat:               ErrorFormatAPIError,
+		EnableTelemetry:           false,
+		ServiceName:               "http-platform-service",
+		ServiceVersion:            "1.0.0",
+		Environment:               "development",
+		OTLPEndpoint:              "localhost:4318",
+		TelemetrySampleAll:        true,
+		TelemetrySampleRatio:      0.1,
+		TelemetryParentBased:      true,
+		EnableTelemetryMetrics:    false,
+		TelemetryMetricsInterval:  60 * time.Second,
+		EnableTelemetryLogs:       false,
+		OTLPInsecure:              true, // The Datadog Agent is typically local
 	}
 }
 
@@ -119,9 +279,86 @@ func (c *Config) Validate() error {
 		return errors.NewConfigError("idleTimeout must be positive")
 	}
 
+	if c.EnableTelemetry && c.EnableTelemetryMetrics && c.TelemetryMetricsInterval < 0 {
+		return errors.NewConfigError("TelemetryMetricsInterval cannot be negative")
+	}
+
+	if c.EnableTelemetry {
+		if err := c.TelemetryExporterConfig().Validate(); err != nil {
+			return errors.NewConfigError("telemetry: " + err.Error())
+		}
+		if err := c.TelemetrySamplingConfig().Validate(); err != nil {
+			return errors.NewConfigError("telemetry: " + err.Error())
+		}
+		if err := c.TelemetryResourceConfig().Validate(); err != nil {
+			return errors.NewConfigError("telemetry: " + err.Error())
+		}
+		if err := c.validateTelemetryInit(); err != nil {
+			return err
+		}
+	}
+
+	if c.OpenAPIDocsPath != "" && c.OpenAPIPath == "" {
+		return errors.NewConfigError("OpenAPIDocsPath requires OpenAPIPath to be set")
+	}
+
+	if c.OpenAPIUI != "" && c.OpenAPIUI != "swagger" && c.OpenAPIUI != "redoc" {
+		return errors.NewConfigError(fmt.Sprintf("invalid OpenAPIUI: '%s' (must be swagger or redoc)", c.OpenAPIUI))
+	}
+
+	if c.OpenAPIAssetsURL != "" && c.OpenAPIAssets != nil {
+		return errors.NewConfigError("OpenAPIAssetsURL and OpenAPIAssets are mutually exclusive")
+	}
+
+	if c.ErrorFormat != "" && c.ErrorFormat != ErrorFormatAPIError && c.ErrorFormat != ErrorFormatProblem {
+		return errors.NewConfigError(fmt.Sprintf("invalid ErrorFormat: '%s' (must be %s or %s)", c.ErrorFormat, ErrorFormatAPIError, ErrorFormatProblem))
+	}
+
+	if err := middleware.ValidateLocales(c.ValidationLocales); err != nil {
+		return errors.NewConfigError("validation: " + err.Error())
+	}
+
 	// Validate CORS configuration
+	if c.EnableCORS {
+		if err := c.validateCORS(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateTelemetryInit checks the initialization mode and its backoff
+func (c *Config) validateTelemetryInit() error {
+	switch c.TelemetryInitMode {
+	case "", TelemetryInitLenient, TelemetryInitStrict, TelemetryInitRetry:
+	default:
+		return errors.NewConfigError(fmt.Sprintf("invalid TelemetryInitMode: '%s' (must be %s, %s or %s)",
+			c.TelemetryInitMode, TelemetryInitLenient, TelemetryInitStrict, TelemetryInitRetry))
+	}
+
+	if c.TelemetryInitBackoff < 0 || c.TelemetryInitMaxBackoff < 0 {
+		return errors.NewConfigError("TelemetryInitBackoff and TelemetryInitMaxBackoff cannot be negative")
+	}
+	if c.TelemetryInitBackoff > 0 && c.TelemetryInitMaxBackoff > 0 && c.TelemetryInitBackoff > c.TelemetryInitMaxBackoff {
+		return errors.NewConfigError("TelemetryInitBackoff cannot exceed TelemetryInitMaxBackoff")
+	}
+
+	return nil
+}
+
+// validateCORS checks origin patterns and the credentials/wildcard rule
+func (c *Config) validateCORS() error {
+	if len(c.AllowedOrigins) == 0 && c.AllowOriginFunc == nil {
+		return errors.NewConfigError("CORS: AllowedOrigins cannot be empty unless AllowOriginFunc is set")
+	}
+
+	if err := middleware.ValidateOrigins(c.AllowedOrigins); err != nil {
+		return errors.NewConfigError("CORS: " + err.Error())
+	}
+
 	// CORS spec: wildcard origin "*" cannot be used with credentials
-	if c.EnableCORS && len(c.AllowedOrigins) == 1 && c.AllowedOrigins[0] == "*" && c.AllowCredentials {
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
 		return errors.NewConfigError("CORS: AllowCredentials cannot be true when AllowedOrigins is [\"*\"]. Either set AllowCredentials to false or specify explicit origins")
 	}
 
@@ -206,6 +443,12 @@ func WithMaxAge(maxAge time.Duration) Option {
 	}
 }
 
+func WithAllowOriginFunc(fn func(c *gin.Context, origin string) bool) Option {
+	return func(c *Config) {
+		c.AllowOriginFunc = fn
+	}
+}
+
 func WithoutTraceID() Option {
 	return func(c *Config) {
 		c.EnableTraceID = false
@@ -242,6 +485,161 @@ func WithTrustedProxies(proxies []string) Option {
 	}
 }
 
+func WithRoutesEndpoint(path string) Option {
+	return func(c *Config) {
+		c.RoutesEndpoint = path
+	}
+}
+
+func WithMetrics(path string) Option {
+	return func(c *Config) {
+		c.MetricsPath = path
+	}
+}
+
+func WithMetricsNamespace(namespace string) Option {
+	return func(c *Config) {
+		c.MetricsNamespace = namespace
+	}
+}
+
+func WithMetricsBuckets(buckets []float64) Option {
+	return func(c *Config) {
+		c.MetricsBuckets = buckets
+	}
+}
+
+func WithHealth(path string) Option {
+	return func(c *Config) {
+		c.HealthPath = path
+	}
+}
+
+func WithOpenAPI(specPath, docsPath string) Option {
+	return func(c *Config) {
+		c.OpenAPIPath = specPath
+		c.OpenAPIDocsPath = docsPath
+	}
+}
+
+func WithOpenAPIUI(ui string) Option {
+	return func(c *Config) {
+		c.OpenAPIUI = ui
+	}
+}
+
+func WithOpenAPIAssetsURL(url string) Option {
+	return func(c *Config) {
+		c.OpenAPIAssetsURL = url
+	}
+}
+
+func WithOpenAPIAssets(handler http.Handler) Option {
+	return func(c *Config) {
+		c.OpenAPIAssets = handler
+	}
+}
+
+func WithErrorFormat(format string) Option {
+	return func(c *Config) {
+		c.ErrorFormat = format
+	}
+}
+
+func WithProblemTypeBaseURI(uri string) Option {
+	return func(c *Config) {
+		c.ProblemTypeBaseURI = uri
+	}
+}
+
+func WithNoRouteHandler(handler gin.HandlerFunc) Option {
+	return func(c *Config) {
+		c.NoRouteHandler = handler
+	}
+}
+
+func WithNoMethodHandler(handler gin.HandlerFunc) Option {
+	return func(c *Config) {
+		c.NoMethodHandler = handler
+	}
+}
+
+func WithAggregatedErrors() Option {
+	return func(c *Config) {
+		c.AggregateErrors = true
+	}
+}
+
+func WithValidationLocales(locales ...string) Option {
+	return func(c *Config) {
+		c.ValidationLocales = locales
+	}
+}
+
+func WithValidationStructFieldNames() Option {
+	return func(c *Config) {
+		c.ValidationStructFieldNames = true
+	}
+}
+
+func WithValidationMessages(locale string, messages map[string]string) Option {
+	return func(c *Config) {
+		if c.ValidationMessages == nil {
+			c.ValidationMessages = make(map[string]map[string]string)
+		}
+		if c.ValidationMessages[locale] == nil {
+			c.ValidationMessages[locale] = make(map[string]string)
+		}
+		for tag, message := range messages {
+			c.ValidationMessages[locale][tag] = message
+		}
+	}
+}
+
+func WithCustomValidator(tag string, fn validator.Func, messages map[string]string) Option {
+	return func(c *Config) {
+		c.CustomValidators = append(c.CustomValidators, middleware.CustomValidator{Tag: tag, Func: fn, Messages: messages})
+	}
+}
+
+// ValidationConfigured reports whether validation messages are configured
+// Without any validation option, gin's global validator is left as it is.
+func (c *Config) ValidationConfigured() bool {
+	return len(c.ValidationLocales) > 0 || c.ValidationStructFieldNames ||
+		len(c.ValidationMessages) > 0 || len(c.CustomValidators) > 0
+}
+
+// ValidationConfig returns the validation message settings of the configuration
+func (c *Config) ValidationConfig() middleware.ValidationConfig {
+	return middleware.ValidationConfig{
+		Locales:          c.ValidationLocales,
+		StructFieldNames: c.ValidationStructFieldNames,
+		Messages:         c.ValidationMessages,
+		Validators:       c.CustomValidators,
+	}
+}
+
+func WithErrorMappers(mappers ...middleware.ErrorMapper) Option {
+	return func(c *Config) {
+		c.ErrorMappers = append(c.ErrorMappers, mappers...)
+	}
+}
+
+// ErrorHandlerOptions returns the ErrorHandler options matching the configuration
+func (c *Config) ErrorHandlerOptions() []middleware.ErrorHandlerOption {
+	var opts []middleware.ErrorHandlerOption
+	if c.ErrorFormat == ErrorFormatProblem {
+		opts = append(opts, middleware.WithProblemDetails(c.ProblemTypeBaseURI))
+	}
+	if c.AggregateErrors {
+		opts = append(opts, middleware.WithErrorAggregation())
+	}
+	for _, mapper := range c.ErrorMappers {
+		opts = append(opts, middleware.WithErrorMapper(mapper))
+	}
+	return opts
+}
+
 func WithTelemetry(serviceName, version, environment, otlpEndpoint string) Option {
 	return func(c *Config) {
 		c.EnableTelemetry = true
@@ -258,8 +656,243 @@ func WithTelemetrySampling(sampleAll bool) Option {
 	}
 }
 
+func WithTelemetrySampleRatio(ratio float64) Option {
+	return func(c *Config) {
+		c.TelemetrySampleAll = false
+		c.TelemetrySampleRatio = ratio
+	}
+}
+
+func WithTelemetrySamplingRules(rules ...SamplingRule) Option {
+	return func(c *Config) {
+		c.TelemetrySamplingRules = append(c.TelemetrySamplingRules, rules...)
+	}
+}
+
+func WithTelemetryErrorSampling() Option {
+	return func(c *Config) {
+		c.TelemetrySampleErrors = true
+	}
+}
+
+func WithoutTelemetryParentSampling() Option {
+	return func(c *Config) {
+		c.TelemetryParentBased = false
+	}
+}
+
+// TelemetrySamplingConfig returns the trace sampling settings of the configuration
+func (c *Config) TelemetrySamplingConfig() telemetry.SamplingConfig {
+	ratio := c.TelemetrySampleRatio
+	if c.TelemetrySampleAll {
+		ratio = 1
+	}
+	return telemetry.SamplingConfig{
+		Ratio:        ratio,
+		ParentBased:  c.TelemetryParentBased,
+		Rules:        c.TelemetrySamplingRules,
+		SampleErrors: c.TelemetrySampleErrors,
+	}
+}
+
 func WithoutTelemetry() Option {
 	return func(c *Config) {
 		c.EnableTelemetry = false
 	}
 }
+
+func WithTelemetryMetricsInterval(interval time.Duration) Option {
+	return func(c *Config) {
+		c.TelemetryMetricsInterval = interval
+	}
+}
+
+func WithSpanHeaders(headers ...string) Option {
+	return func(c *Config) {
+		c.TelemetrySpanHeaders = append(c.TelemetrySpanHeaders, headers...)
+	}
+}
+
+func WithSpanQueryParams(params ...string) Option {
+	return func(c *Config) {
+		c.TelemetrySpanQueryParams = append(c.TelemetrySpanQueryParams, params...)
+	}
+}
+
+func WithSpanUserID(userID func(*gin.Context) string) Option {
+	return func(c *Config) {
+		c.TelemetryUserID = userID
+	}
+}
+
+func WithSpanTenantID(tenantID func(*gin.Context) string) Option {
+	return func(c *Config) {
+		c.TelemetryTenantID = tenantID
+	}
+}
+
+// SpanAttributesConfig returns the request attributes added to the request span
+func (c *Config) SpanAttributesConfig() middleware.SpanAttributesConfig {
+	return middleware.SpanAttributesConfig{
+		Headers:     c.TelemetrySpanHeaders,
+		QueryParams: c.TelemetrySpanQueryParams,
+		UserID:      c.TelemetryUserID,
+		TenantID:    c.TelemetryTenantID,
+	}
+}
+
+func WithTelemetryMetrics() Option {
+	return func(c *Config) {
+		c.EnableTelemetryMetrics = true
+	}
+}
+
+func WithoutTelemetryMetrics() Option {
+	return func(c *Config) {
+		c.EnableTelemetryMetrics = false
+	}
+}
+
+func WithTelemetryLogs() Option {
+	return func(c *Config) {
+		c.EnableTelemetryLogs = true
+	}
+}
+
+func WithoutTelemetryLogs() Option {
+	return func(c *Config) {
+		c.EnableTelemetryLogs = false
+	}
+}
+
+func WithTelemetryStdout() Option {
+	return func(c *Config) {
+		c.TelemetryExporter = TelemetryExporterStdout
+	}
+}
+
+func WithTelemetryFile(path string) Option {
+	return func(c *Config) {
+		c.TelemetryExporter = TelemetryExporterFile
+		c.TelemetryFilePath = path
+	}
+}
+
+func WithOTLPProtocol(protocol string) Option {
+	return func(c *Config) {
+		c.OTLPProtocol = protocol
+	}
+}
+
+func WithOTLPTLS(caFile, certFile, keyFile string) Option {
+	return func(c *Config) {
+		c.OTLPInsecure = false
+		c.OTLPCAFile = caFile
+		c.OTLPCertFile = certFile
+		c.OTLPKeyFile = keyFile
+	}
+}
+
+func WithOTLPHeaders(headers map[string]string) Option {
+	return func(c *Config) {
+		if c.OTLPHeaders == nil {
+			c.OTLPHeaders = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			c.OTLPHeaders[name] = value
+		}
+	}
+}
+
+func WithOTLPCompression(compression string) Option {
+	return func(c *Config) {
+		c.OTLPCompression = compression
+	}
+}
+
+func WithOTLPURLPath(path string) Option {
+	return func(c *Config) {
+		c.OTLPURLPath = path
+	}
+}
+
+func WithOTLPTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.OTLPTimeout = timeout
+	}
+}
+
+func WithOTLPRetry(initialInterval, maxInterval, maxElapsedTime time.Duration) Option {
+	return func(c *Config) {
+		c.OTLPRetry = &OTLPRetryConfig{
+			Enabled:         true,
+			InitialInterval: initialInterval,
+			MaxInterval:     maxInterval,
+			MaxElapsedTime:  maxElapsedTime,
+		}
+	}
+}
+
+func WithoutOTLPRetry() Option {
+	return func(c *Config) {
+		c.OTLPRetry = &OTLPRetryConfig{Enabled: false}
+	}
+}
+
+func WithStrictTelemetry() Option {
+	return func(c *Config) {
+		c.TelemetryInitMode = TelemetryInitStrict
+	}
+}
+
+func WithTelemetryInitRetry(initialBackoff, maxBackoff time.Duration) Option {
+	return func(c *Config) {
+		c.TelemetryInitMode = TelemetryInitRetry
+		c.TelemetryInitBackoff = initialBackoff
+		c.TelemetryInitMaxBackoff = maxBackoff
+	}
+}
+
+func WithTelemetryResourceDetectors(detectors ...string) Option {
+	return func(c *Config) {
+		c.TelemetryResourceDetectors = append(c.TelemetryResourceDetectors, detectors...)
+	}
+}
+
+func WithTelemetryResourceAttributes(attributes map[string]string) Option {
+	return func(c *Config) {
+		if c.TelemetryResourceAttributes == nil {
+			c.TelemetryResourceAttributes = make(map[string]string, len(attributes))
+		}
+		for key, value := range attributes {
+			c.TelemetryResourceAttributes[key] = value
+		}
+	}
+}
+
+// TelemetryResourceConfig returns the resource settings of the configuration
+func (c *Config) TelemetryResourceConfig() telemetry.ResourceConfig {
+	return telemetry.ResourceConfig{
+		Detectors:  c.TelemetryResourceDetectors,
+		Attributes: c.TelemetryResourceAttributes,
+	}
+}
+
+// TelemetryExporterConfig returns the exporter settings of the configuration
+func (c *Config) TelemetryExporterConfig() telemetry.ExporterConfig {
+	return telemetry.ExporterConfig{
+		Exporter:    c.TelemetryExporter,
+		Protocol:    c.OTLPProtocol,
+		Endpoint:    c.OTLPEndpoint,
+		Insecure:    c.OTLPInsecure,
+		CAFile:      c.OTLPCAFile,
+		CertFile:    c.OTLPCertFile,
+		KeyFile:     c.OTLPKeyFile,
+		Headers:     c.OTLPHeaders,
+		Compression: c.OTLPCompression,
+		URLPath:     c.OTLPURLPath,
+		Timeout:     c.OTLPTimeout,
+		Retry:       c.OTLPRetry,
+		FilePath:    c.TelemetryFilePath,
+	}
+}
@@ -0,0 +1,342 @@
+// Package openapi generates OpenAPI 3.1 documents from the routes registered on the platform
+// Request and response types are reflected into JSON Schema (2020-12, as used by OpenAPI 3.1),
+// including the go-playground/validator rules declared in binding/validate struct tags.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI specification version of generated documents
+const Version = "3.1.0"
+
+// Document is an OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+}
+
+// Info holds the API metadata
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag describes an operation tag
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Components holds reusable schemas
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathItem holds the operations of a single path, keyed by lowercase HTTP method
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a path, query or header parameter
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes the request body of an operation
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Route is the generator input describing a registered route
+type Route struct {
+	Method      string
+	Path        string // gin path template, e.g. "/users/:id"
+	Summary     string
+	Description string
+	Tags        []string
+	Request     any
+	Responses   []RouteResponse
+}
+
+// RouteResponse is a declared route response used as generator input
+type RouteResponse struct {
+	Status int
+	Body   any
+}
+
+// Media types of request, response and error bodies
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+)
+
+// Option configures Generate
+type Option func(*generator)
+
+// WithProblemDetails documents error responses as RFC 9457 problem details
+// (application/problem+json) instead of ApiError, matching ErrorHandler's WithProblemDetails.
+func WithProblemDetails() Option {
+	return func(g *generator) {
+		g.problemDetails = true
+	}
+}
+
+// Generate builds an OpenAPI document for the given routes
+// Every operation documents the platform error shape (ApiError, or ProblemDetails with
+// WithProblemDetails) as its default response.
+func Generate(info Info, routes []Route, opts ...Option) *Document {
+	g := newGenerator()
+	for _, opt := range opts {
+		opt(g)
+	}
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	tags := make(map[string]struct{})
+	for _, route := range routes {
+		templatePath, pathParams := convertPath(route.Path)
+		item, ok := doc.Paths[templatePath]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[templatePath] = item
+		}
+
+		(*item)[strings.ToLower(route.Method)] = g.operation(route, pathParams)
+		for _, tag := range route.Tags {
+			tags[tag] = struct{}{}
+		}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	g.addErrorSchemas()
+	doc.Components = Components{Schemas: g.schemas}
+	return doc
+}
+
+// operation builds the operation for a single route
+func (g *generator) operation(route Route, pathParams []string) *Operation {
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Responses:   make(map[string]*Response),
+	}
+
+	declared := make(map[string]bool)
+	hasValidation := false
+	if route.Request != nil {
+		params, body, validated := g.requestParts(reflect.TypeOf(route.Request))
+		op.Parameters = params
+		hasValidation = validated
+		for _, p := range params {
+			if p.In == "path" {
+				declared[p.Name] = true
+			}
+		}
+		if body != nil && methodHasBody(route.Method) {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{jsonContentType: {Schema: body}},
+			}
+		}
+	}
+
+	// Path params not declared by the request type are documented as strings
+	for _, name := range pathParams {
+		if !declared[name] {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"},
+			})
+		}
+	}
+
+	for _, resp := range route.Responses {
+		r := &Response{Description: http.StatusText(resp.Status)}
+		if resp.Body != nil {
+			r.Content = map[string]*MediaType{jsonContentType: {Schema: g.schemaFor(reflect.TypeOf(resp.Body))}}
+		}
+		op.Responses[strconv.Itoa(resp.Status)] = r
+	}
+	if len(route.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: "Successful response"}
+	}
+
+	if hasValidation || op.RequestBody != nil {
+		op.Responses["400"] = g.errorResponse("Invalid request (validation errors are listed in " + g.validationMember() + ")")
+	}
+	op.Responses["default"] = g.errorResponse("Error response")
+
+	return op
+}
+
+// errorResponse documents a response using the error schema of the configured format
+func (g *generator) errorResponse(description string) *Response {
+	if g.problemDetails {
+		return &Response{
+			Description: description,
+			Content:     map[string]*MediaType{problemContentType: {Schema: &Schema{Ref: schemaRef("ProblemDetails")}}},
+		}
+	}
+	return &Response{
+		Description: description,
+		Content:     map[string]*MediaType{jsonContentType: {Schema: &Schema{Ref: schemaRef("ApiError")}}},
+	}
+}
+
+// validationMember returns the member of error responses listing validation errors
+func (g *generator) validationMember() string {
+	if g.problemDetails {
+		return "invalid-params"
+	}
+	return "cause"
+}
+
+// errorDetailsSchema is the schema of the domain error metadata
+func errorDetailsSchema() *Schema {
+	return &Schema{
+		Type:                 "object",
+		Description:          "Metadata of the domain error (e.g., {\"order_id\": \"42\"})",
+		AdditionalProperties: &Schema{},
+	}
+}
+
+// addErrorSchemas registers the schemas of the error responses produced by ErrorHandler:
+// ApiError and ValidationError, or ProblemDetails and InvalidParam with WithProblemDetails
+func (g *generator) addErrorSchemas() {
+	if g.problemDetails {
+		g.addProblemSchemas()
+		return
+	}
+
+	g.schemas["ValidationError"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"field":   {Type: "string", Description: "Name of the invalid field"},
+			"reason":  {Type: "string", Description: "Violated validation rule (e.g., \"min=3\")"},
+			"message": {Type: "string", Description: "Human-readable message in the language of the request (Accept-Language)"},
+		},
+		Required: []string{"field", "reason"},
+	}
+	g.schemas["ApiError"] = &Schema{
+		Type:        "object",
+		Description: "Error response produced by the platform ErrorHandler",
+		Properties: map[string]*Schema{
+			"message": {Type: "string", Description: "Human-readable error message"},
+			"error":   {Type: "string", Description: "HTTP status text"},
+			"status":  {Type: "integer", Description: "HTTP status code"},
+			"code":    {Type: "string", Description: "Stable machine-readable error code (e.g., \"ORDER_NOT_FOUND\")"},
+			"details": errorDetailsSchema(),
+			"cause": {
+				Type:        "array",
+				Description: "Validation errors, or every error of the request when errors are aggregated",
+				Items: &Schema{OneOf: []*Schema{
+					{Ref: schemaRef("ValidationError")},
+					{Ref: schemaRef("ApiError")},
+				}},
+			},
+		},
+		Required: []string{"message", "error", "status"},
+	}
+}
+
+// addProblemSchemas registers the RFC 9457 problem details schemas
+func (g *generator) addProblemSchemas() {
+	g.schemas["InvalidParam"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"name":    {Type: "string", Description: "Name of the invalid field"},
+			"reason":  {Type: "string", Description: "Violated validation rule (e.g., \"min=3\")"},
+			"message": {Type: "string", Description: "Human-readable message in the language of the request (Accept-Language)"},
+		},
+		Required: []string{"name", "reason"},
+	}
+	g.schemas["ProblemDetails"] = &Schema{
+		Type:        "object",
+		Description: "RFC 9457 problem details produced by the platform ErrorHandler",
+		Properties: map[string]*Schema{
+			"type":     {Type: "string", Format: "uri-reference", Description: "Problem type (about:blank when no type base URI is configured)"},
+			"title":    {Type: "string", Description: "HTTP status text"},
+			"status":   {Type: "integer", Description: "HTTP status code"},
+			"detail":   {Type: "string", Description: "Human-readable error message"},
+			"instance": {Type: "string", Description: "Request path"},
+			"trace_id": {Type: "string", Description: "Trace ID of the request"},
+			"code":     {Type: "string", Description: "Stable machine-readable error code (e.g., \"ORDER_NOT_FOUND\")"},
+			"details":  errorDetailsSchema(),
+			"invalid-params": {
+				Type:        "array",
+				Description: "Validation errors",
+				Items:       &Schema{Ref: schemaRef("InvalidParam")},
+			},
+			"errors": {
+				Type:        "array",
+				Description: "Every error of the request when errors are aggregated",
+				Items:       &Schema{Ref: schemaRef("ProblemDetails")},
+			},
+		},
+		Required: []string{"type", "title", "status"},
+	}
+}
+
+// convertPath converts a gin path template to OpenAPI form and returns the path parameter names
+// e.g. "/users/:id/*file" becomes "/users/{id}/{file}"
+func convertPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// methodHasBody reports whether requests with the given method carry a body
+func methodHasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(_ context.Context, _ string, _ middleware.Fields)  {}
+func (nopLogger) Error(_ context.Context, _ string, _ middleware.Fields) {}
+func (nopLogger) Warn(_ context.Context, _ string, _ middleware.Fields)  {}
+func (nopLogger) Debug(_ context.Context, _ string, _ middleware.Fields) {}
+func (nopLogger) Close() error                                           { return nil }
+
+type signupRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// errorResponses returns the bodies ErrorHandler writes for validation errors and aggregated
+// domain errors, with the given options
+func errorResponses(t *testing.T, opts ...middleware.ErrorHandlerOption) []map[string]any {
+	t.Helper()
+	if err := middleware.ConfigureValidation(middleware.ValidationConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.ErrorHandler(nopLogger{}, append(opts, middleware.WithErrorAggregation())...))
+	engine.POST("/validation", func(c *gin.Context) {
+		var req signupRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.Error(err)
+		}
+	})
+	engine.POST("/aggregated", func(c *gin.Context) {
+		_ = c.Error(platformErrors.NewBadRequestError("Invalid input",
+			platformErrors.WithErrorCode("BAD_INPUT"), platformErrors.WithErrorDetail("field", "sku")))
+		_ = c.Error(platformErrors.NewNotFoundError("Order not found"))
+	})
+
+	var bodies []map[string]any
+	for _, target := range []string{"/validation", "/aggregated"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"password":"short"}`))
+		req.Header.Set("Content-Type", "application/json")
+		engine.ServeHTTP(rec, req)
+
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: body is not JSON: %v", target, err)
+		}
+		bodies = append(bodies, body)
+	}
+	return bodies
+}
+
+// mismatch returns why value does not match schema, checking members and required members
+// through $ref and oneOf, or "" when it matches
+func mismatch(schemas map[string]*Schema, schema *Schema, value any, path string) string {
+	if schema.Ref != "" {
+		return mismatch(schemas, schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], value, path)
+	}
+	if len(schema.OneOf) > 0 {
+		reasons := make([]string, 0, len(schema.OneOf))
+		for _, candidate := range schema.OneOf {
+			reason := mismatch(schemas, candidate, value, path)
+			if reason == "" {
+				return ""
+			}
+			reasons = append(reasons, reason)
+		}
+		return strings.Join(reasons, " / ")
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if schema.Type != "object" {
+			return path + ": object where " + schema.Type + " is documented"
+		}
+		if schema.Properties == nil {
+			return ""
+		}
+		for key, member := range v {
+			prop, ok := schema.Properties[key]
+			if !ok {
+				return path + "." + key + ": undocumented member"
+			}
+			if reason := mismatch(schemas, prop, member, path+"."+key); reason != "" {
+				return reason
+			}
+		}
+		for _, key := range schema.Required {
+			if _, ok := v[key]; !ok {
+				return path + "." + key + ": missing required member"
+			}
+		}
+	case []any:
+		if schema.Type != "array" {
+			return path + ": array where " + schema.Type + " is documented"
+		}
+		for _, item := range v {
+			if reason := mismatch(schemas, schema.Items, item, path+"[]"); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
+
+func TestErrorSchemasMatchErrorHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		genOpts     []Option
+		handlerOpts []middleware.ErrorHandlerOption
+		contentType string
+		schema      string
+	}{
+		{"api error", nil, nil, "application/json", "ApiError"},
+		{"problem details", []Option{WithProblemDetails()}, []middleware.ErrorHandlerOption{middleware.WithProblemDetails("")}, "application/problem+json", "ProblemDetails"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Generate(Info{Title: "test", Version: "1"}, []Route{{Method: http.MethodPost, Path: "/signup", Request: signupRequest{}}}, tt.genOpts...)
+
+			op := (*doc.Paths["/signup"])["post"]
+			for _, status := range []string{"400", "default"} {
+				media, ok := op.Responses[status].Content[tt.contentType]
+				if !ok || media.Schema.Ref != schemaRef(tt.schema) {
+					t.Fatalf("response %s is not documented as %s %s: %+v", status, tt.contentType, tt.schema, op.Responses[status].Content)
+				}
+			}
+
+			for _, body := range errorResponses(t, tt.handlerOpts...) {
+				if reason := mismatch(doc.Components.Schemas, &Schema{Ref: schemaRef(tt.schema)}, body, tt.schema); reason != "" {
+					raw, _ := json.Marshal(body)
+					t.Errorf("%s\nresponse: %s", reason, raw)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationCauseIsFlat(t *testing.T) {
+	body := errorResponses(t)[0]
+
+	cause, ok := body["cause"].([]any)
+	if !ok || len(cause) != 2 {
+		t.Fatalf("cause = %v, want two validation errors", body["cause"])
+	}
+	for _, item := range cause {
+		fieldErr, ok := item.(map[string]any)
+		if !ok || fieldErr["field"] == nil || fieldErr["reason"] == nil || fieldErr["message"] == nil {
+			t.Errorf("cause item = %v, want {field, reason, message}", item)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema (2020-12) object as used by OpenAPI 3.1
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// generator reflects Go types into schemas, collecting named structs as reusable components
+type generator struct {
+	schemas        map[string]*Schema
+	names          map[reflect.Type]string
+	problemDetails bool // Errors are documented as RFC 9457 problem details
+}
+
+// newGenerator creates a generator with an empty component registry
+func newGenerator() *generator {
+	return &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRef returns the JSON pointer of a component schema
+func schemaRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// schemaFor returns the schema of a type; named structs are referenced through components
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: schemaRef(g.componentName(t))}
+	default:
+		// Interfaces and custom marshalers can hold any JSON value
+		return &Schema{}
+	}
+}
+
+// componentName registers a named struct as a component and returns its name
+// Types with the same name from different packages are prefixed with the package name.
+func (g *generator) componentName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+
+	// Reserve the name before reflecting the fields so recursive types terminate
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema reflects the JSON body fields of a struct
+// Fields tagged with uri, form or header only (without a json tag) are not part of the body.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened, as encoding/json does
+		jsonTag := field.Tag.Get("json")
+		if field.Anonymous && jsonTag == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := g.structSchema(ft)
+				for name, prop := range embedded.Properties {
+					schema.Properties[name] = prop
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+		}
+
+		if jsonTag == "" && isParameterField(field) {
+			continue
+		}
+
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		prop, required := g.fieldSchema(field)
+		schema.Properties[name] = prop
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// fieldSchema reflects a struct field and applies its validation rules
+// Returns whether the field is required
+func (g *generator) fieldSchema(field reflect.StructField) (*Schema, bool) {
+	// JSON Schema 2020-12 allows constraints next to $ref, so referenced schemas are annotated in place
+	schema := g.schemaFor(field.Type)
+	required := applyRules(schema, validationRules(field), field.Type)
+	return schema, required
+}
+
+// requestParts splits a request type into parameters and a JSON body schema
+// Returns whether any field declares validation rules
+func (g *generator) requestParts(t reflect.Type) ([]*Parameter, *Schema, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, g.schemaFor(t), false
+	}
+
+	var params []*Parameter
+	hasBody := false
+	validated := false
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if len(validationRules(field)) > 0 {
+			validated = true
+		}
+
+		for _, loc := range []struct{ tag, in string }{{"uri", "path"}, {"form", "query"}, {"header", "header"}} {
+			name := tagName(field.Tag.Get(loc.tag))
+			if name == "" || name == "-" {
+				continue
+			}
+			schema, required := g.fieldSchema(field)
+			params = append(params, &Parameter{
+				Name:     name,
+				In:       loc.in,
+				Required: required || loc.in == "path",
+				Schema:   schema,
+			})
+		}
+
+		jsonTag := field.Tag.Get("json")
+		if (jsonTag != "" && jsonTag != "-") || (jsonTag == "" && !isParameterField(field)) {
+			hasBody = true
+		}
+	}
+
+	if !hasBody {
+		return params, nil, validated
+	}
+	return params, &Schema{Ref: schemaRef(g.componentName(t))}, validated
+}
+
+// isParameterField reports whether a field is bound from the path, query or headers
+func isParameterField(field reflect.StructField) bool {
+	for _, tag := range []string{"uri", "form", "header"} {
+		if name := tagName(field.Tag.Get(tag)); name != "" && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFieldName returns the JSON property name of a field, or false if it is skipped
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := tagName(tag); name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// tagName returns the name part of a struct tag value ("name,omitempty" -> "name")
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+// validationRules returns the validator rules of a field from its binding and validate tags
+func validationRules(field reflect.StructField) []string {
+	var rules []string
+	for _, tag := range []string{"binding", "validate"} {
+		if value := field.Tag.Get(tag); value != "" && value != "-" {
+			rules = append(rules, strings.Split(value, ",")...)
+		}
+	}
+	return rules
+}
+
+// applyRules translates validator rules into schema constraints and returns whether the field is required
+// Rules after "dive" apply to the items of a slice or the values of a map.
+func applyRules(schema *Schema, rules []string, t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	required := false
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			target := schema.Items
+			if target == nil {
+				target = schema.AdditionalProperties
+			}
+			if target != nil && target.Ref == "" && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				applyRules(target, rules[i+1:], t.Elem())
+			}
+			return required
+		case "min", "max", "len":
+			applySizeRule(schema, name, param, t)
+		case "gt", "gte", "lt", "lte":
+			applyComparisonRule(schema, name, param, t)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, enumValue(value, t))
+			}
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid3", "uuid4", "uuid5":
+			schema.Format = "uuid"
+		case "ip", "ipv4":
+			schema.Format = "ipv4"
+		case "ipv6":
+			schema.Format = "ipv6"
+		case "hostname", "hostname_rfc1123":
+			schema.Format = "hostname"
+		case "datetime":
+			schema.Format = "date-time"
+			schema.Description = "Layout: " + param
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "alphanum":
+			schema.Pattern = "^[a-zA-Z0-9]+$"
+		case "numeric":
+			schema.Pattern = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+		case "number":
+			schema.Pattern = "^[0-9]+$"
+		case "lowercase":
+			schema.Pattern = "^[^A-Z]*$"
+		case "uppercase":
+			schema.Pattern = "^[^a-z]*$"
+		}
+	}
+	return required
+}
+
+// applySizeRule applies min, max and len according to the field kind
+// (length for strings, item count for slices and maps, value for numbers)
+func applySizeRule(schema *Schema, rule, param string, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.String:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		if rule == "min" || rule == "len" {
+			schema.MinLength = &n
+		}
+		if rule == "max" || rule == "len" {
+			schema.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		if rule == "min" || rule == "len" {
+			schema.MinItems = &n
+		}
+		if rule == "max" || rule == "len" {
+			schema.MaxItems = &n
+		}
+	default:
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if rule == "min" || rule == "len" {
+			schema.Minimum = &v
+		}
+		if rule == "max" || rule == "len" {
+			schema.Maximum = &v
+		}
+	}
+}
+
+// applyComparisonRule applies gt, gte, lt and lte
+// For strings and collections they constrain the length, for numbers the value.
+func applyComparisonRule(schema *Schema, rule, param string, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		switch rule {
+		case "gt":
+			n++
+		case "lt":
+			n--
+		}
+		sizeRule := "min"
+		if rule == "lt" || rule == "lte" {
+			sizeRule = "max"
+		}
+		applySizeRule(schema, sizeRule, strconv.Itoa(n), t)
+	default:
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		switch rule {
+		case "gt":
+			schema.ExclusiveMinimum = &v
+		case "gte":
+			schema.Minimum = &v
+		case "lt":
+			schema.ExclusiveMaximum = &v
+		case "lte":
+			schema.Maximum = &v
+		}
+	}
+}
+
+// enumValue converts a oneof value to the field's JSON type
+func enumValue(value string, t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
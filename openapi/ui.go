@@ -0,0 +1,79 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// UI identifiers accepted by DocsPage
+const (
+	UISwagger = "swagger"
+	UIRedoc   = "redoc"
+)
+
+// CDN URLs of the UI scripts and styles, pinned to the versions of the uiassets package
+const (
+	SwaggerAssetsURL = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.18.2"
+	RedocAssetsURL   = "https://cdn.jsdelivr.net/npm/redoc@2.0.0/bundles"
+)
+
+// DefaultAssetsURL returns the CDN URL of the assets of a UI ("swagger" or "redoc")
+func DefaultAssetsURL(ui string) string {
+	if ui == UIRedoc {
+		return RedocAssetsURL
+	}
+	return SwaggerAssetsURL
+}
+
+// swaggerPage renders Swagger UI; the verbs are the title, the assets URL (twice) and the
+// document URL
+const swaggerPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>%s</title>
+  <link rel="stylesheet" href="%s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%s/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: %s, dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// redocPage renders Redoc; the verbs are the title, the document URL and the assets URL
+const redocPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>%s</title>
+</head>
+<body>
+  <redoc spec-url="%s"></redoc>
+  <script src="%s/redoc.standalone.js"></script>
+</body>
+</html>
+`
+
+// DocsPage returns the HTML page of the documentation UI ("swagger" or "redoc")
+// The page loads its scripts and styles from assetsURL: a CDN (DefaultAssetsURL) or the path
+// where the uiassets handler is mounted.
+func DocsPage(ui, title, specURL, assetsURL string) string {
+	assetsURL = html.EscapeString(strings.TrimSuffix(assetsURL, "/"))
+	if ui == UIRedoc {
+		return fmt.Sprintf(redocPage, html.EscapeString(title), html.EscapeString(specURL), assetsURL)
+	}
+
+	// json.Marshal escapes <, > and & so the URL is safe inside the script element
+	jsURL, _ := json.Marshal(specURL)
+	return fmt.Sprintf(swaggerPage, html.EscapeString(title), assetsURL, assetsURL, jsURL)
+}
@@ -0,0 +1,23 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocsPageAssetsURL(t *testing.T) {
+	tests := []struct {
+		ui, assetsURL, wantScript string
+	}{
+		{UISwagger, DefaultAssetsURL(UISwagger), SwaggerAssetsURL + "/swagger-ui-bundle.js"},
+		{UIRedoc, DefaultAssetsURL(UIRedoc), RedocAssetsURL + "/redoc.standalone.js"},
+		{UISwagger, "/docs/assets/", "/docs/assets/swagger-ui-bundle.js"},
+		{UIRedoc, "https://mirror.internal/redoc", "https://mirror.internal/redoc/redoc.standalone.js"},
+	}
+	for _, tt := range tests {
+		page := DocsPage(tt.ui, "Orders", "/openapi.json", tt.assetsURL)
+		if !strings.Contains(page, `src="`+tt.wantScript+`"`) {
+			t.Errorf("%s page with assets %s does not load %s:\n%s", tt.ui, tt.assetsURL, tt.wantScript, page)
+		}
+	}
+}
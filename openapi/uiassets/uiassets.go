@@ -0,0 +1,76 @@
+// Package uiassets embeds the Swagger UI and Redoc scripts and styles, so the documentation
+// UI loads nothing from third-party hosts
+// It adds about 720KB to the binary; without it, the UI loads the same versions from a CDN.
+//
+// Example:
+//
+//	platform, _ := httpplatform.New(cfg,
+//	    httpplatform.WithOpenAPI("/openapi.json", "/docs"),
+//	    httpplatform.WithOpenAPIAssets(uiassets.Handler()),
+//	)
+package uiassets
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// assets holds the gzip-compressed UI bundles, see assets/README.md for versions and licenses
+//
+//go:embed assets/*.gz
+var assets embed.FS
+
+// assetCacheControl is sent with the UI assets, which only change with the module version
+const assetCacheControl = "public, max-age=86400"
+
+// Handler returns a handler serving the asset named by the last element of the request path
+// (e.g., "/docs/assets/swagger-ui-bundle.js")
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeAsset(w, r, r.URL.Path)
+	})
+}
+
+// ServeAsset writes the UI asset with the given name (e.g., "swagger-ui-bundle.js")
+// The compressed bytes are sent as-is to clients accepting gzip. Unknown names answer 404.
+func ServeAsset(w http.ResponseWriter, r *http.Request, name string) {
+	name = path.Base(name)
+	data, err := assets.ReadFile("assets/" + name + ".gz")
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", assetContentType(name))
+	header.Set("Cache-Control", assetCacheControl)
+	header.Add("Vary", "Accept-Encoding")
+
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		header.Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+		return
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, zr)
+}
+
+// assetContentType returns the media type of an asset from its extension
+func assetContentType(name string) string {
+	if strings.HasSuffix(name, ".css") {
+		return "text/css; charset=utf-8"
+	}
+	return "text/javascript; charset=utf-8"
+}
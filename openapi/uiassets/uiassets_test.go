@@ -0,0 +1,54 @@
+package uiassets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		asset          string
+		acceptEncoding string
+		wantStatus     int
+		wantEncoding   string
+		wantType       string
+	}{
+		{"gzip", "swagger-ui-bundle.js", "gzip, deflate", http.StatusOK, "gzip", "text/javascript; charset=utf-8"},
+		{"identity", "swagger-ui.css", "", http.StatusOK, "", "text/css; charset=utf-8"},
+		{"redoc", "redoc.standalone.js", "gzip", http.StatusOK, "gzip", "text/javascript; charset=utf-8"},
+		{"unknown", "missing.js", "gzip", http.StatusNotFound, "", ""},
+		{"traversal", "../openapi.go", "", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/docs/assets/"+tt.asset, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if rec.Header().Get("Cache-Control") == "" {
+				t.Error("Cache-Control is not set")
+			}
+			if tt.wantEncoding == "" && !strings.Contains(rec.Body.String(), "swagger-ui") {
+				t.Errorf("body is not the decompressed asset: %.80s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@
 //
 // Key features:
 //   - Functional options pattern for configuration
-//   - Automatic middleware chain (TraceID, ErrorHandler, ContextCancellation, CORS, Telemetry, Logger)
+//   - Automatic middleware chain (Metrics, Telemetry, TraceID, ErrorHandler, ContextCancellation, CORS, Logger)
 //   - Logger injection (any logger that implements middleware.Logger interface)
 //   - Graceful shutdown with context support
 //   - Clean API for route registration
@@ -37,6 +37,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 	"time"
@@ -45,18 +46,20 @@ import (
 	"github.com/edaniel30/http-platform-go/internal/adapters"
 	"github.com/edaniel30/http-platform-go/internal/telemetry"
 	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/edaniel30/http-platform-go/openapi"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Platform is the main HTTP server platform
 // It encapsulates server lifecycle, routing, and middleware management
 type Platform struct {
-	config           Config
-	router           *adapters.GinRouter
-	server           *http.Server
-	telemetryManager *telemetry.TelemetryManager
-	mu               sync.RWMutex
-	started          bool
+	config    Config
+	router    *adapters.GinRouter
+	server    *http.Server
+	telemetry *platformTelemetry // nil when telemetry is disabled
+	mu        sync.RWMutex
+	started   bool
 }
 
 // New creates a new HTTP platform with the given configuration and options
@@ -74,39 +77,33 @@ func New(cfg Config, opts ...Option) (*Platform, error) {
 		return nil, err
 	}
 
-	// Initialize telemetry if enabled
-	var tm *telemetry.TelemetryManager
-	if cfg.EnableTelemetry {
-		telemetryCfg := telemetry.Config{
-			ServiceName:    cfg.ServiceName,
-			ServiceVersion: cfg.ServiceVersion,
-			Environment:    cfg.Environment,
-			OTLPEndpoint:   cfg.OTLPEndpoint,
-			SampleAll:      cfg.TelemetrySampleAll,
+	// Validation messages are registered on gin's global validator, only when asked for: other
+	// platforms and gin users of the process keep their validator otherwise
+	if cfg.ValidationConfigured() {
+		if err := middleware.ConfigureValidation(cfg.ValidationConfig()); err != nil {
+			return nil, errors.NewConfigError("validation: " + err.Error())
 		}
+	}
 
-		ctx := context.Background()
-		var err error
-		tm, err = telemetry.Init(ctx, telemetryCfg)
-		if err != nil {
-			cfg.Logger.Error(ctx, "failed to initialize telemetry", middleware.Fields{"error": err})
-			// Don't fail the entire platform startup, just log the error
-			tm = nil
-		} else {
-			cfg.Logger.Info(ctx, "telemetry initialized successfully", middleware.Fields{
-				"service":  cfg.ServiceName,
-				"version":  cfg.ServiceVersion,
-				"endpoint": cfg.OTLPEndpoint,
-			})
+	// Initialize telemetry if enabled (see TelemetryInitMode for failures)
+	var tel *platformTelemetry
+	if cfg.EnableTelemetry {
+		tel = newPlatformTelemetry(cfg)
+		if err := tel.start(context.Background()); err != nil {
+			return nil, err
 		}
 	}
 
 	router := adapters.NewGinRouter(cfg)
 
+	if tel != nil {
+		router.Health().Register("telemetry", tel.healthCheck)
+	}
+
 	p := &Platform{
-		config:           cfg,
-		router:           router,
-		telemetryManager: tm,
+		config:    cfg,
+		router:    router,
+		telemetry: tel,
 	}
 
 	return p, nil
@@ -121,9 +118,18 @@ func (p *Platform) Start(ctx context.Context) error {
 		p.mu.Unlock()
 		return errors.ErrAlreadyStarted()
 	}
+
+	// Fail before listening if any route could not be registered
+	if err := p.router.ValidateRoutes(); err != nil {
+		p.mu.Unlock()
+		p.config.Logger.Error(ctx, "invalid route table", middleware.Fields{"error": err})
+		return err
+	}
 	p.started = true
 	p.mu.Unlock()
 
+	p.logRouteTable(ctx)
+
 	addr := fmt.Sprintf(":%d", p.config.Port)
 	p.server = &http.Server{
 		Addr:           addr,
@@ -172,9 +178,9 @@ func (p *Platform) Start(ctx context.Context) error {
 	}
 
 	// Shutdown telemetry if initialized (always attempt even if server shutdown failed)
-	if p.telemetryManager != nil {
+	if tm := p.releaseTelemetry(shutdownCtx); tm != nil {
 		p.config.Logger.Info(shutdownCtx, "shutting down telemetry...", middleware.Fields{})
-		if err := p.telemetryManager.Shutdown(shutdownCtx); err != nil {
+		if err := tm.Shutdown(shutdownCtx); err != nil {
 			p.config.Logger.Error(shutdownCtx, "error shutting down telemetry", middleware.Fields{"error": err})
 			shutdownErrors = append(shutdownErrors, errors.NewRuntimeError("telemetry shutdown failed", err))
 		} else {
@@ -224,8 +230,8 @@ func (p *Platform) Stop(ctx context.Context) error {
 	}
 
 	// Shutdown telemetry if initialized (always attempt even if server shutdown failed)
-	if p.telemetryManager != nil {
-		if err := p.telemetryManager.Shutdown(ctx); err != nil {
+	if tm := p.releaseTelemetry(ctx); tm != nil {
+		if err := tm.Shutdown(ctx); err != nil {
 			p.config.Logger.Error(ctx, "error shutting down telemetry", middleware.Fields{"error": err})
 			shutdownErrors = append(shutdownErrors, errors.NewRuntimeError("telemetry shutdown failed", err))
 		}
@@ -241,12 +247,66 @@ func (p *Platform) Stop(ctx context.Context) error {
 	return nil
 }
 
+// releaseTelemetry stops retrying telemetry initialization and returns the manager to shut down
+func (p *Platform) releaseTelemetry(ctx context.Context) *telemetry.TelemetryManager {
+	if p.telemetry == nil {
+		return nil
+	}
+	return p.telemetry.release(ctx)
+}
+
+// logRouteTable logs a one-line summary of the registered routes
+func (p *Platform) logRouteTable(ctx context.Context) {
+	routes := p.router.Routes()
+	summary := make([]string, 0, len(routes))
+	for _, route := range routes {
+		summary = append(summary, route.Method+" "+route.Path)
+	}
+
+	p.config.Logger.Info(ctx, "routes registered", middleware.Fields{
+		"count":  len(routes),
+		"routes": summary,
+	})
+}
+
+// Routes returns every registered route with its full path (including BasePath),
+// handler name, attached middleware and route options, in registration order
+func (p *Platform) Routes() []RouteInfo {
+	return p.router.Routes()
+}
+
+// OpenAPI generates the OpenAPI 3.1 document of the registered routes
+// Use it to export the document at build time, e.g. json.Marshal(platform.OpenAPI())
+func (p *Platform) OpenAPI() *openapi.Document {
+	return p.router.OpenAPI()
+}
+
+// ValidateRoutes reports routes that could not be registered (duplicates, conflicting
+// wildcards, invalid paths) and group CORS policies with malformed origin patterns.
+// Start calls it automatically and refuses to start on error.
+func (p *Platform) ValidateRoutes() error {
+	return p.router.ValidateRoutes()
+}
+
 // Use adds custom middleware to the platform
 // Middleware is applied in the order it's registered
 func (p *Platform) Use(middleware ...gin.HandlerFunc) {
 	p.router.Use(middleware...)
 }
 
+// Handle registers a route with per-route options
+//
+// Example:
+//
+//	platform.Handle(http.MethodPost, "/reports", createReport,
+//	    httpplatform.Route.Timeout(30*time.Second),
+//	    httpplatform.Route.MaxBody(10<<20),
+//	    httpplatform.Route.Tags("reports"),
+//	)
+func (p *Platform) Handle(method, relativePath string, handler gin.HandlerFunc, opts ...middleware.RouteOption) {
+	p.router.Handle(method, relativePath, handler, opts...)
+}
+
 // GET registers a GET route
 func (p *Platform) GET(relativePath string, handlers ...gin.HandlerFunc) {
 	p.router.GET(relativePath, handlers...)
@@ -282,12 +342,90 @@ func (p *Platform) HEAD(relativePath string, handlers ...gin.HandlerFunc) {
 	p.router.HEAD(relativePath, handlers...)
 }
 
+// Meter returns an OpenTelemetry meter for custom instruments (counters, histograms, gauges)
+// Measurements are exported with the platform metrics; without telemetry the meter of the
+// global provider is returned, which is a no-op unless the application sets one.
+//
+// Example:
+//
+//	orders, _ := platform.Meter("orders").Int64Counter("orders.created")
+//	orders.Add(ctx, 1)
+func (p *Platform) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return p.telemetry.Manager().Meter(name, opts...)
+}
+
+// Metrics returns the Prometheus metrics, or nil when metrics are disabled (see WithMetrics)
+// Register application metrics in Metrics().Registry() to expose them on the same endpoint.
+func (p *Platform) Metrics() *middleware.Metrics {
+	return p.router.Metrics()
+}
+
+// Health returns the health check registry, served at HealthPath (see WithHealth)
+// With telemetry enabled it includes a "telemetry" check reporting the initialization state
+// and the spans exported, failed and dropped.
+//
+// Example:
+//
+//	platform.Health().Register("database", func(ctx context.Context) httpplatform.HealthResult {
+//	    if err := db.PingContext(ctx); err != nil {
+//	        return httpplatform.HealthResult{Status: httpplatform.HealthDown, Error: err.Error()}
+//	    }
+//	    return httpplatform.HealthResult{Status: httpplatform.HealthUp}
+//	})
+func (p *Platform) Health() *middleware.Health {
+	return p.router.Health()
+}
+
+// Renderers returns the response formats of the platform, negotiated by Render and ErrorHandler
+// The registry starts as a copy of the default one (see RegisterRenderer); formats registered
+// here only apply to this platform. Register formats before the server handles requests.
+//
+// Example:
+//
+//	platform.Renderers().Register("application/yaml", httpplatform.NewRenderer("application/yaml; charset=utf-8",
+//	    func(w io.Writer, v any) error { return yaml.NewEncoder(w).Encode(v) }))
+func (p *Platform) Renderers() *RendererRegistry {
+	return p.router.Renderers()
+}
+
+// NoRoute replaces the handlers of requests matching no route
+// By default they receive a 404 NotFoundError in the platform error format.
+func (p *Platform) NoRoute(handlers ...gin.HandlerFunc) {
+	p.router.NoRoute(handlers...)
+}
+
+// NoMethod replaces the handlers of requests whose path only exists with other methods
+// By default they receive a 405 MethodNotAllowedError with an Allow header.
+func (p *Platform) NoMethod(handlers ...gin.HandlerFunc) {
+	p.router.NoMethod(handlers...)
+}
+
 // Group creates a new route group with the given prefix
 // Useful for organizing related routes under a common path
 func (p *Platform) Group(relativePath string, handlers ...gin.HandlerFunc) *adapters.GinRouterGroup {
 	return p.router.Group(relativePath, handlers...)
 }
 
+// ReloadCORSOrigins replaces the globally allowed CORS origins at runtime
+// Patterns are validated first; on error the current origins are kept.
+// Group-level overrides registered with GinRouterGroup.CORS are not affected.
+func (p *Platform) ReloadCORSOrigins(origins []string) error {
+	cors := p.router.CORS()
+	if cors == nil {
+		return errors.NewConfigError("CORS is disabled")
+	}
+
+	if p.config.AllowCredentials && slices.Contains(origins, "*") {
+		return errors.NewConfigError("CORS: AllowCredentials cannot be true when AllowedOrigins is [\"*\"]")
+	}
+
+	if err := cors.Origins().Reload(origins); err != nil {
+		return errors.NewConfigError("CORS: " + err.Error())
+	}
+
+	return nil
+}
+
 // Router returns the underlying router for advanced usage
 func (p *Platform) Router() *adapters.GinRouter {
 	return p.router
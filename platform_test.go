@@ -0,0 +1,76 @@
+package httpplatform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(_ context.Context, _ string, _ Fields)  {}
+func (nopLogger) Error(_ context.Context, _ string, _ Fields) {}
+func (nopLogger) Warn(_ context.Context, _ string, _ Fields)  {}
+func (nopLogger) Debug(_ context.Context, _ string, _ Fields) {}
+func (nopLogger) Close() error                                { return nil }
+
+func newTestConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Mode = gin.TestMode
+	cfg.Logger = nopLogger{}
+	return cfg
+}
+
+// foreignValidator is a binding.StructValidator that ConfigureValidation cannot configure
+type foreignValidator struct{}
+
+func (foreignValidator) ValidateStruct(any) error { return nil }
+func (foreignValidator) Engine() any              { return nil }
+
+func TestNewLeavesGlobalValidatorUnlessConfigured(t *testing.T) {
+	previous := binding.Validator
+	binding.Validator = foreignValidator{}
+	t.Cleanup(func() { binding.Validator = previous })
+
+	if _, err := New(newTestConfig()); err != nil {
+		t.Fatalf("New() without validation options = %v, want the validator left untouched", err)
+	}
+
+	_, err := New(newTestConfig(), WithValidationLocales("en"))
+	if err == nil || !strings.Contains(err.Error(), "validation") {
+		t.Fatalf("New() with validation options = %v, want the validator configured", err)
+	}
+}
+
+func TestTelemetryMetricsAndLogsAreOptIn(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.EnableTelemetryMetrics || cfg.EnableTelemetryLogs {
+		t.Fatalf("defaults export metrics=%v logs=%v, want traces only", cfg.EnableTelemetryMetrics, cfg.EnableTelemetryLogs)
+	}
+
+	for _, opt := range []Option{WithTelemetryMetrics(), WithTelemetryLogs()} {
+		opt(&cfg)
+	}
+	if !cfg.EnableTelemetryMetrics || !cfg.EnableTelemetryLogs {
+		t.Errorf("options export metrics=%v logs=%v, want both", cfg.EnableTelemetryMetrics, cfg.EnableTelemetryLogs)
+	}
+}
+
+func TestPlatformRenderersAreIsolated(t *testing.T) {
+	first, err := New(newTestConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := New(newTestConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.Renderers().Register("application/yaml", NewRenderer("application/yaml", nil))
+	if _, ok := second.Renderers().Negotiate("application/yaml"); ok {
+		t.Error("format registered on one platform reached another platform")
+	}
+}
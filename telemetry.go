@@ -0,0 +1,244 @@
+package httpplatform
+
+import (
+	"cmp"
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/internal/telemetry"
+	"github.com/edaniel30/http-platform-go/middleware"
+)
+
+// Default backoff between telemetry initialization attempts in retry mode
+const (
+	defaultTelemetryInitBackoff    = time.Second
+	defaultTelemetryInitMaxBackoff = time.Minute
+)
+
+// Telemetry states reported by the "telemetry" health check
+const (
+	telemetryRunning  = "running"
+	telemetryRetrying = "retrying"
+	telemetryFailed   = "failed"
+	telemetryStopped  = "stopped"
+)
+
+// platformTelemetry initializes telemetry according to TelemetryInitMode and owns its manager
+type platformTelemetry struct {
+	cfg    telemetry.Config
+	config Config
+
+	mu       sync.RWMutex
+	manager  *telemetry.TelemetryManager
+	state    string
+	attempts int
+	lastErr  error
+
+	cancel context.CancelFunc // stops the retry loop
+	done   chan struct{}      // closed when the retry loop exits, nil without retry
+}
+
+// newPlatformTelemetry creates the telemetry of the configuration, not yet initialized
+func newPlatformTelemetry(cfg Config) *platformTelemetry {
+	return &platformTelemetry{
+		cfg: telemetry.Config{
+			ServiceName:    cfg.ServiceName,
+			ServiceVersion: cfg.ServiceVersion,
+			Environment:    cfg.Environment,
+			Sampling:       cfg.TelemetrySamplingConfig(),
+
+			Exporter: cfg.TelemetryExporterConfig(),
+
+			Metrics:         cfg.EnableTelemetryMetrics,
+			MetricsInterval: cfg.TelemetryMetricsInterval,
+
+			Logs: cfg.EnableTelemetryLogs,
+
+			Resource: cfg.TelemetryResourceConfig(),
+
+			// Root spans keep the trace ID of an inbound X-Trace-Id (see middleware.Telemetry)
+			IDGenerator: middleware.TraceIDGenerator(),
+
+			// Lenient mode does not care whether the collector is reachable at startup
+			Probe: cfg.TelemetryInitMode == TelemetryInitStrict || cfg.TelemetryInitMode == TelemetryInitRetry,
+		},
+		config: cfg,
+	}
+}
+
+// errTelemetryStopped is returned by attempts finishing after the platform released telemetry
+var errTelemetryStopped = stderrors.New("telemetry stopped")
+
+// start makes the first initialization attempt
+// On failure, strict mode returns the error, retry mode keeps attempting in the background
+// while the collector is unreachable, and lenient mode logs the error and runs without telemetry.
+func (t *platformTelemetry) start(ctx context.Context) error {
+	err := t.attempt(ctx)
+	if err == nil {
+		return nil
+	}
+
+	switch t.config.TelemetryInitMode {
+	case TelemetryInitStrict:
+		return errors.NewRuntimeError("failed to initialize telemetry", err)
+	case TelemetryInitRetry:
+		if !retryableInitError(err) {
+			return nil
+		}
+		retryCtx, cancel := context.WithCancel(context.Background())
+		t.cancel = cancel
+		t.done = make(chan struct{})
+		go t.retry(retryCtx)
+	}
+	return nil
+}
+
+// attempt initializes telemetry once, records the outcome and returns the initialization error
+func (t *platformTelemetry) attempt(ctx context.Context) error {
+	tm, err := telemetry.Init(ctx, t.cfg)
+
+	t.mu.Lock()
+	if t.state == telemetryStopped {
+		// Released while initializing: nobody else will shut this manager down
+		t.mu.Unlock()
+		if err == nil {
+			_ = tm.Shutdown(ctx)
+		}
+		return errTelemetryStopped
+	}
+	t.attempts++
+	attempts := t.attempts
+	if err != nil {
+		t.lastErr = err
+		t.state = telemetryFailed
+		if t.config.TelemetryInitMode == TelemetryInitRetry && retryableInitError(err) {
+			t.state = telemetryRetrying
+		}
+	} else {
+		t.manager = tm
+		t.state = telemetryRunning
+		t.lastErr = nil
+	}
+	t.mu.Unlock()
+
+	if err != nil {
+		t.config.Logger.Error(ctx, "failed to initialize telemetry", middleware.Fields{
+			"error":   err,
+			"attempt": attempts,
+			"mode":    cmp.Or(t.config.TelemetryInitMode, TelemetryInitLenient),
+		})
+		return err
+	}
+
+	t.config.Logger.Info(ctx, "telemetry initialized successfully", middleware.Fields{
+		"service":  t.config.ServiceName,
+		"version":  t.config.ServiceVersion,
+		"endpoint": t.config.OTLPEndpoint,
+		"exporter": cmp.Or(t.config.TelemetryExporter, TelemetryExporterOTLP),
+		"attempt":  attempts,
+	})
+	return nil
+}
+
+// retryableInitError reports whether an initialization error may go away: the probe export
+// failed (e.g., the collector is not up yet). Other errors are configuration errors
+// (unreadable certificates, unwritable export file) that every attempt would repeat.
+func retryableInitError(err error) bool {
+	return stderrors.Is(err, telemetry.ErrProbeFailed)
+}
+
+// retry attempts initialization with exponential backoff until it succeeds, fails with an error
+// retrying cannot fix, or ctx is cancelled
+// Tracers, meters and loggers obtained from the global providers before success start
+// exporting once it succeeds.
+func (t *platformTelemetry) retry(ctx context.Context) {
+	defer close(t.done)
+
+	backoff := cmp.Or(t.config.TelemetryInitBackoff, defaultTelemetryInitBackoff)
+	maxBackoff := cmp.Or(t.config.TelemetryInitMaxBackoff, defaultTelemetryInitMaxBackoff)
+
+	for {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := t.attempt(ctx); err == nil || !retryableInitError(err) {
+			return
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// Manager returns the telemetry manager, or nil while telemetry is not initialized
+func (t *platformTelemetry) Manager() *telemetry.TelemetryManager {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.manager
+}
+
+// release stops the retry loop and hands over the manager for shutdown
+// It returns nil when telemetry was never initialized or was already released.
+func (t *platformTelemetry) release(ctx context.Context) *telemetry.TelemetryManager {
+	if t.cancel != nil {
+		t.cancel()
+		select {
+		case <-t.done:
+		case <-ctx.Done():
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tm := t.manager
+	t.manager = nil
+	t.state = telemetryStopped
+	return tm
+}
+
+// healthCheck reports the initialization state and the span export status
+// Telemetry problems degrade the service but never take it down.
+func (t *platformTelemetry) healthCheck(_ context.Context) middleware.HealthResult {
+	t.mu.RLock()
+	tm, state, attempts, lastErr := t.manager, t.state, t.attempts, t.lastErr
+	t.mu.RUnlock()
+
+	details := map[string]any{
+		"state":    state,
+		"attempts": attempts,
+		"exporter": cmp.Or(t.config.TelemetryExporter, TelemetryExporterOTLP),
+	}
+	if state != telemetryRunning {
+		result := middleware.HealthResult{Status: middleware.HealthDegraded, Details: details}
+		if lastErr != nil {
+			result.Error = lastErr.Error()
+		}
+		return result
+	}
+
+	status := tm.Status()
+	details["spans_exported"] = status.SpansExported
+	details["spans_failed"] = status.SpansFailed
+	details["spans_dropped"] = status.SpansDropped
+	details["consecutive_failures"] = status.ConsecutiveFailures
+	if !status.LastExport.IsZero() {
+		details["last_export"] = status.LastExport.UTC().Format(time.RFC3339)
+	}
+
+	result := middleware.HealthResult{Status: middleware.HealthUp, Details: details}
+	if status.ConsecutiveFailures > 0 {
+		result.Status = middleware.HealthDegraded
+		result.Error = status.LastError
+		details["last_error_time"] = status.LastErrorTime.UTC().Format(time.RFC3339)
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package httpplatform
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edaniel30/http-platform-go/internal/telemetry"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// unreachableEndpoint returns a local address nothing listens on
+func unreachableEndpoint(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr
+}
+
+func newTestTelemetry(endpoint string, opts ...Option) *platformTelemetry {
+	cfg := newTestConfig()
+	opts = append([]Option{
+		WithTelemetry("orders", "1.0.0", "test", endpoint),
+		WithOTLPTimeout(time.Second),
+		WithoutOTLPRetry(),
+	}, opts...)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newPlatformTelemetry(cfg)
+}
+
+func TestStrictTelemetryProbesCollector(t *testing.T) {
+	var exports, spans atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			return
+		}
+		exports.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		var req coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			t.Errorf("probe request is not an OTLP export: %v", err)
+		}
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans.Add(int32(len(ss.Spans)))
+			}
+		}
+	}))
+	defer collector.Close()
+
+	tel := newTestTelemetry(collector.Listener.Addr().String(), WithStrictTelemetry())
+	if err := tel.start(context.Background()); err != nil {
+		t.Fatalf("start() = %v, want the reachable collector accepted", err)
+	}
+	if tm := tel.release(context.Background()); tm != nil {
+		_ = tm.Shutdown(context.Background())
+	}
+	if exports.Load() == 0 {
+		t.Error("collector received no probe request")
+	}
+	if spans.Load() != 0 {
+		t.Errorf("probe exported %d spans, want none", spans.Load())
+	}
+
+	tel = newTestTelemetry(unreachableEndpoint(t), WithStrictTelemetry())
+	err := tel.start(context.Background())
+	if !stderrors.Is(err, telemetry.ErrProbeFailed) {
+		t.Fatalf("start() = %v, want %v", err, telemetry.ErrProbeFailed)
+	}
+}
+
+func TestTelemetryInitRetry(t *testing.T) {
+	t.Run("unreachable collector", func(t *testing.T) {
+		tel := newTestTelemetry(unreachableEndpoint(t), WithTelemetryInitRetry(time.Hour, time.Hour))
+		if err := tel.start(context.Background()); err != nil {
+			t.Fatalf("start() = %v, want nil in retry mode", err)
+		}
+		defer tel.release(context.Background())
+
+		if tel.done == nil {
+			t.Fatal("no retry loop started")
+		}
+		if state := tel.healthCheck(context.Background()).Details["state"]; state != telemetryRetrying {
+			t.Errorf("state = %v, want %s", state, telemetryRetrying)
+		}
+	})
+
+	t.Run("configuration error", func(t *testing.T) {
+		missingCA := filepath.Join(t.TempDir(), "ca.pem")
+		tel := newTestTelemetry("otel-collector.internal:4318",
+			WithOTLPTLS(missingCA, "", ""), WithTelemetryInitRetry(time.Millisecond, time.Millisecond))
+		if err := tel.start(context.Background()); err != nil {
+			t.Fatalf("start() = %v, want nil in retry mode", err)
+		}
+		defer tel.release(context.Background())
+
+		if tel.done != nil {
+			t.Error("retry loop started for an error retrying cannot fix")
+		}
+		details := tel.healthCheck(context.Background()).Details
+		if details["state"] != telemetryFailed || details["attempts"] != 1 {
+			t.Errorf("state = %v after %v attempts, want %s after 1", details["state"], details["attempts"], telemetryFailed)
+		}
+	})
+}
@@ -0,0 +1,338 @@
+package httpplatform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/edaniel30/http-platform-go/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// TypedOption configures a handler created with Typed
+type TypedOption func(*typedConfig)
+
+// typedConfig holds the settings of a typed handler
+type typedConfig struct {
+	status int
+}
+
+// ResponseStatus sets the status code of successful responses (default 200)
+// With 204 No Content the response value is not rendered.
+func ResponseStatus(status int) TypedOption {
+	return func(cfg *typedConfig) {
+		cfg.status = status
+	}
+}
+
+// ginContextKey is the context key holding the gin context of a typed handler
+type ginContextKey struct{}
+
+// GinContext returns the gin context of the request handled by a typed handler
+// Returns nil when ctx does not come from Typed (e.g., in unit tests).
+func GinContext(ctx context.Context) *gin.Context {
+	c, _ := ctx.Value(ginContextKey{}).(*gin.Context)
+	return c
+}
+
+// Typed adapts a plain function into a gin handler
+// The request value is bound from every source before fn runs:
+//   - Path parameters (uri tag)
+//   - Query parameters (form tag)
+//   - Headers (header tag)
+//   - Body: JSON (json tag), or form fields (form tag) for form and multipart requests;
+//     other content types are rejected with 415 Unsupported Media Type
+//
+// Body values take precedence over query parameters; path parameters and headers take
+// precedence over both.
+//
+// The struct is then validated once with the binding/validate rules. Binding, validation and
+// fn errors are passed to ErrorHandler through c.Error, so they share the platform error format.
+// On success the response is rendered with the configured status, in the format negotiated
+// from the Accept header (see Render).
+//
+// fn receives the request context, so it can be unit tested without gin:
+//
+//	func CreateOrder(ctx context.Context, req CreateOrderRequest) (OrderResponse, error) { ... }
+//
+//	platform.POST("/orders", httpplatform.Typed(CreateOrder, httpplatform.ResponseStatus(http.StatusCreated)))
+//
+// Use struct{} as the request type for handlers without input.
+func Typed[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error), opts ...TypedOption) gin.HandlerFunc {
+	cfg := typedConfig{status: http.StatusOK}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(c *gin.Context) {
+		var req Req
+		if err := bindRequest(c, &req); err != nil {
+			c.Error(err)
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), ginContextKey{}, c)
+		resp, err := fn(ctx, req)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		if cfg.status == http.StatusNoContent {
+			c.Status(cfg.status)
+			return
+		}
+		middleware.Render(c, cfg.status, resp)
+	}
+}
+
+// bindRequest binds every request source into req and validates the result once
+// Binding sources one by one with ShouldBindXXX would validate after each source and
+// report required fields of the other sources as missing.
+//
+// Query parameters are bound before the body: form binding applies default= values, so binding
+// them last would overwrite values of the body. Structs with json fields only take query
+// parameters named by form tags (see queryParams).
+// Path parameters and headers, bound by explicit tags only, are bound last and take precedence.
+func bindRequest(c *gin.Context, req any) error {
+	if reflect.TypeOf(req).Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := bindQueryAndBody(c, req); err != nil {
+		return err
+	}
+
+	if len(c.Params) > 0 {
+		params := make(map[string][]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = []string{p.Value}
+		}
+		if err := binding.MapFormWithTag(req, params, "uri"); err != nil {
+			return platformErrors.NewBadRequestError(fmt.Sprintf("Invalid path parameters: %v", err))
+		}
+	}
+
+	if names := headerTags(reflect.TypeOf(req).Elem()); len(names) > 0 {
+		headers := make(map[string][]string, len(names))
+		for _, name := range names {
+			if values := c.Request.Header.Values(name); len(values) > 0 {
+				headers[name] = values
+			}
+		}
+		if err := binding.MapFormWithTag(req, headers, "header"); err != nil {
+			return platformErrors.NewBadRequestError(fmt.Sprintf("Invalid headers: %v", err))
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(req)
+}
+
+// bindQueryAndBody binds the query parameters, then decodes the request body, if any,
+// according to its content type
+// Form bodies share the form tag with the query, so both are bound in a single pass with body
+// fields taking precedence. Other content types than JSON and forms are rejected with 415.
+func bindQueryAndBody(c *gin.Context, req any) error {
+	query := queryParams(reflect.TypeOf(req).Elem(), c.Request.URL.Query())
+	if c.Request.Body == nil || c.Request.Body == http.NoBody || c.Request.ContentLength == 0 {
+		return mapQuery(req, query)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(c.ContentType())
+	switch {
+	case contentType == binding.MIMEPOSTForm:
+		if err := c.Request.ParseForm(); err != nil {
+			return err
+		}
+		return mapFormBody(req, mergeForm(query, c.Request.PostForm))
+
+	case contentType == binding.MIMEMultipartPOSTForm:
+		form, err := c.MultipartForm()
+		if err != nil {
+			return err
+		}
+		return mapFormBody(req, mergeForm(query, form.Value))
+
+	case isJSONContentType(contentType):
+		if err := mapQuery(req, query); err != nil {
+			return err
+		}
+		// Fields absent from the body keep their query (or default) value
+		err := json.NewDecoder(c.Request.Body).Decode(req)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+
+	default:
+		return platformErrors.NewUnsupportedMediaTypeError(
+			fmt.Sprintf("Unsupported Content-Type '%s' (expected application/json, %s or %s)",
+				contentType, binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm))
+	}
+}
+
+// isJSONContentType reports whether a media type is JSON
+// JSON is assumed when no content type is sent; structured suffixes such as
+// application/merge-patch+json are JSON as well.
+func isJSONContentType(contentType string) bool {
+	return contentType == "" || contentType == binding.MIMEJSON || strings.HasSuffix(contentType, "+json")
+}
+
+// mergeForm returns the query parameters overridden by the form fields of the body
+func mergeForm(query, body map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(query)+len(body))
+	for key, values := range query {
+		merged[key] = values
+	}
+	for key, values := range body {
+		merged[key] = values
+	}
+	return merged
+}
+
+// mapQuery maps query parameters into req
+func mapQuery(req any, query map[string][]string) error {
+	if err := binding.MapFormWithTag(req, query, "form"); err != nil {
+		return platformErrors.NewBadRequestError(fmt.Sprintf("Invalid query parameters: %v", err))
+	}
+	return nil
+}
+
+// mapFormBody maps form fields into req
+func mapFormBody(req any, form map[string][]string) error {
+	if err := binding.MapFormWithTag(req, form, "form"); err != nil {
+		return platformErrors.NewBadRequestError(fmt.Sprintf("Invalid form fields: %v", err))
+	}
+	return nil
+}
+
+// queryParams returns the query parameters that may be bound into a request of type t
+// Form binding maps untagged fields by their Go name; when t has body (json) fields, only
+// parameters named by form tags are kept, so "?Role=admin" cannot set a json-only Role field.
+func queryParams(t reflect.Type, query map[string][]string) map[string][]string {
+	names, hasBody := formTags(t)
+	if !hasBody {
+		return query
+	}
+
+	allowed := make(map[string][]string, len(names))
+	for _, name := range names {
+		if values, ok := query[name]; ok {
+			allowed[name] = values
+		}
+	}
+	return allowed
+}
+
+// formTagCache caches the form tag names of request types and whether they have json fields
+var formTagCache sync.Map // reflect.Type -> formTagInfo
+
+// formTagInfo describes the query and body fields of a request type
+type formTagInfo struct {
+	names   []string
+	hasBody bool
+}
+
+// formTags returns the names declared with form tags, including nested structs, and whether
+// a field is declared with a json tag
+func formTags(t reflect.Type) ([]string, bool) {
+	if cached, ok := formTagCache.Load(t); ok {
+		info := cached.(formTagInfo)
+		return info.names, info.hasBody
+	}
+
+	var info formTagInfo
+	visited := make(map[reflect.Type]bool)
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+
+			if tag := field.Tag.Get("json"); tag != "" && tag != "-" {
+				info.hasBody = true
+			}
+			if tag := field.Tag.Get("form"); tag != "" && tag != "-" {
+				name, _, _ := strings.Cut(tag, ",")
+				info.names = append(info.names, name)
+				continue
+			}
+
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collect(ft)
+			}
+		}
+	}
+	collect(t)
+
+	formTagCache.Store(t, info)
+	return info.names, info.hasBody
+}
+
+// headerTagCache caches the header tag names of request types
+var headerTagCache sync.Map // reflect.Type -> []string
+
+// headerTags returns the header names declared with header tags, including nested structs
+func headerTags(t reflect.Type) []string {
+	if cached, ok := headerTagCache.Load(t); ok {
+		return cached.([]string)
+	}
+
+	var names []string
+	visited := make(map[reflect.Type]bool)
+	var collect func(reflect.Type)
+	collect = func(t reflect.Type) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+
+			if tag := field.Tag.Get("header"); tag != "" && tag != "-" {
+				name, _, _ := strings.Cut(tag, ",")
+				names = append(names, name)
+				continue
+			}
+
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collect(ft)
+			}
+		}
+	}
+	collect(t)
+
+	headerTagCache.Store(t, names)
+	return names
+}
@@ -0,0 +1,105 @@
+package httpplatform
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	platformErrors "github.com/edaniel30/http-platform-go/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type listRequest struct {
+	ID     string `uri:"id"`
+	Page   int    `form:"page,default=1" json:"page"`
+	Size   int    `form:"size,default=20" json:"size"`
+	Tenant string `header:"X-Tenant" json:"tenant"`
+}
+
+func newBindContext(method, target, contentType, body string, params gin.Params) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	if body == "" {
+		c.Request = httptest.NewRequest(method, target, nil)
+	}
+	if contentType != "" {
+		c.Request.Header.Set("Content-Type", contentType)
+	}
+	c.Request.Header.Set("X-Tenant", "acme")
+	c.Params = params
+	return c
+}
+
+func TestBindRequestSourcePrecedence(t *testing.T) {
+	params := gin.Params{{Key: "id", Value: "42"}}
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		want        listRequest
+	}{
+		{"defaults", "/", "", "", listRequest{ID: "42", Page: 1, Size: 20, Tenant: "acme"}},
+		{"query", "/?page=3", "", "", listRequest{ID: "42", Page: 3, Size: 20, Tenant: "acme"}},
+		{"json body over default", "/", "application/json", `{"page":5}`, listRequest{ID: "42", Page: 5, Size: 20, Tenant: "acme"}},
+		{"json body over query", "/?page=3&size=50", "application/json", `{"page":5}`, listRequest{ID: "42", Page: 5, Size: 50, Tenant: "acme"}},
+		{"json without content type", "/", "", `{"page":5}`, listRequest{ID: "42", Page: 5, Size: 20, Tenant: "acme"}},
+		{"json suffix", "/", "application/merge-patch+json", `{"page":5}`, listRequest{ID: "42", Page: 5, Size: 20, Tenant: "acme"}},
+		{"headers over body", "/", "application/json", `{"tenant":"other"}`, listRequest{ID: "42", Page: 1, Size: 20, Tenant: "acme"}},
+		{"form body over query", "/?page=3&size=50", "application/x-www-form-urlencoded", "page=5", listRequest{ID: "42", Page: 5, Size: 50, Tenant: "acme"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newBindContext(http.MethodPost, tt.target, tt.contentType, tt.body, params)
+
+			var req listRequest
+			if err := bindRequest(c, &req); err != nil {
+				t.Fatalf("bindRequest: %v", err)
+			}
+			if req != tt.want {
+				t.Errorf("got %+v, want %+v", req, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindRequestUnsupportedMediaType(t *testing.T) {
+	c := newBindContext(http.MethodPost, "/", "text/plain", "page=5", nil)
+
+	var req listRequest
+	err := bindRequest(c, &req)
+
+	var mediaErr *platformErrors.UnsupportedMediaTypeError
+	if !errors.As(err, &mediaErr) {
+		t.Fatalf("error = %v, want UnsupportedMediaTypeError", err)
+	}
+	if mediaErr.Status() != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", mediaErr.Status(), http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestBindRequestQueryCannotSetBodyFields(t *testing.T) {
+	type updateUser struct {
+		Name   string `json:"name"`
+		Role   string `json:"role"`
+		Notify bool   `form:"notify" json:"-"`
+	}
+
+	for _, body := range []string{"", `{"name":"Ada"}`} {
+		c := newBindContext(http.MethodPost, "/?Role=admin&role=admin&notify=true", "application/json", body, nil)
+
+		var req updateUser
+		if err := bindRequest(c, &req); err != nil {
+			t.Fatalf("bindRequest: %v", err)
+		}
+		if req.Role != "" {
+			t.Errorf("body %q: Role = %q, want it left unset by the query", body, req.Role)
+		}
+		if !req.Notify {
+			t.Errorf("body %q: Notify = false, want the form-tagged field bound from the query", body)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package httpplatform
 import (
 	"net/http"
 
+	"github.com/edaniel30/http-platform-go/internal/adapters"
 	config "github.com/edaniel30/http-platform-go/models"
 	"github.com/gin-gonic/gin"
 )
@@ -14,6 +15,9 @@ type Config = config.Config
 // Option type
 type Option = config.Option
 
+// RouteInfo describes a registered route (see Platform.Routes)
+type RouteInfo = adapters.RouteInfo
+
 // Gin Framework Types
 // These types are exported to avoid direct gin-gonic/gin imports in consuming applications
 
@@ -39,6 +43,9 @@ type Router interface {
 	// Use adds middleware to the router
 	Use(middleware ...MiddlewareFunc)
 
+	// Handle registers a route with per-route options (Route.Timeout, Route.MaxBody, Route.NoLog, Route.Tags)
+	Handle(method, relativePath string, handler HandlerFunc, opts ...RouteOption)
+
 	// GET registers a GET route
 	GET(relativePath string, handlers ...HandlerFunc)
 
@@ -70,6 +77,9 @@ type RouterGroup interface {
 	// Use adds middleware to the group
 	Use(middleware ...MiddlewareFunc)
 
+	// Handle registers a route in the group with per-route options
+	Handle(method, relativePath string, handler HandlerFunc, opts ...RouteOption)
+
 	// GET registers a GET route in the group
 	GET(relativePath string, handlers ...HandlerFunc)
 
@@ -93,4 +103,8 @@ type RouterGroup interface {
 
 	// Group creates a nested route group
 	Group(relativePath string, handlers ...HandlerFunc) RouterGroup
+
+	// CORS overrides the CORS policy for every route under the group
+	// Malformed origin patterns make Start (and ValidateRoutes) fail instead of panicking.
+	CORS(cfg CORSConfig)
 }